@@ -6,26 +6,58 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
 	"github.com/k8sgpt-ai/k8sgpt/pkg/analyzer"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/cache"
 	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/snapshot"
 	"github.com/schollz/progressbar/v3"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 var (
-	explain   bool
-	backend   string
-	output    string
-	filters   []string
-	language  string
-	nocache   bool
-	namespace string
+	explain        bool
+	backend        string
+	output         string
+	filters        []string
+	language       string
+	nocache        bool
+	namespace      string
+	model          string
+	temperature    float32
+	maxTokens      int
+	concurrency    int
+	rpm            int
+	tpm            int
+	minSeverity    string
+	withLogs       bool
+	logLines       int64
+	anonymize      bool
+	watch          bool
+	metricsAddress string
+	fromSnapshot   string
+	allContexts    bool
+	contextNames   []string
+	selector       string
+	excludeNs      []string
 )
 
+var severityLabels = map[analyzer.Severity]string{
+	analyzer.SeverityCritical: "Critical:",
+	analyzer.SeverityWarning:  "Warning:",
+	analyzer.SeverityInfo:     "Info:",
+}
+
+var severityColors = map[analyzer.Severity]*color.Color{
+	analyzer.SeverityCritical: color.New(color.FgRed),
+	analyzer.SeverityWarning:  color.New(color.FgYellow),
+	analyzer.SeverityInfo:     color.New(color.FgCyan),
+}
+
 // AnalyzeCmd represents the problems command
 var AnalyzeCmd = &cobra.Command{
 	Use:     "analyze",
@@ -45,111 +77,287 @@ var AnalyzeCmd = &cobra.Command{
 		if backend != "" {
 			backendType = backend
 		}
-		// get the token with viper
-		token := viper.GetString(fmt.Sprintf("%s_key", backendType))
-		// check if nil
-		if token == "" {
-			color.Red("No %s key set. Please run k8sgpt auth", backendType)
+		// get the backend settings with viper
+		backendConfig := ai.LoadBackendConfig(backendType)
+		// override the backend settings if flags are provided
+		if cmd.Flags().Changed("model") {
+			backendConfig.Model = model
+		}
+		if cmd.Flags().Changed("temperature") {
+			backendConfig.Temperature = &temperature
+		}
+		if cmd.Flags().Changed("max-tokens") {
+			backendConfig.MaxTokens = maxTokens
+		}
+		if cmd.Flags().Changed("requests-per-minute") {
+			backendConfig.RequestsPerMinute = rpm
+		}
+		if cmd.Flags().Changed("tokens-per-minute") {
+			backendConfig.TokensPerMinute = tpm
+		}
+
+		aiClient, err := ai.NewClient(backendType, backendConfig)
+		if err != nil {
+			color.Red("Error: %v", err)
 			os.Exit(1)
 		}
 
-		var aiClient ai.IAI
-		switch backendType {
-		case "openai":
-			aiClient = &ai.OpenAIClient{}
-			if err := aiClient.Configure(token, language); err != nil {
-				color.Red("Error: %v", err)
+		severity, err := analyzer.ParseSeverity(minSeverity)
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+
+		ctx := context.Background()
+		// the clusters to analyze when analyzing several contexts
+		var contexts []string
+		switch {
+		case allContexts:
+			contexts, err = kubernetes.ListContexts(kubernetes.DefaultLoader.Kubeconfig)
+			if err != nil {
+				color.Red("Error reading kubeconfig contexts: %v", err)
 				os.Exit(1)
 			}
-		default:
-			color.Red("Backend not supported")
+		case len(contextNames) != 0:
+			contexts = contextNames
+		}
+		if len(contexts) != 0 && (fromSnapshot != "" || watch) {
+			color.Red("Error: --all-contexts and --contexts cannot be used with --from-snapshot or --watch")
 			os.Exit(1)
 		}
 
-		ctx := context.Background()
-		// Get kubernetes client from viper
-		client := viper.Get("kubernetesClient").(*kubernetes.Client)
+		var (
+			client *kubernetes.Client
+			// analyzedAt is the time of the snapshot analyzed, if any
+			analyzedAt time.Time
+		)
+		switch {
+		case len(contexts) != 0:
+			// each cluster gets its own client
+		case fromSnapshot != "":
+			// a snapshot has no logs and does not change
+			if withLogs || watch {
+				color.Red("Error: --with-logs and --watch cannot be used with --from-snapshot")
+				os.Exit(1)
+			}
+			file, err := os.Open(fromSnapshot)
+			if err != nil {
+				color.Red("Error: %v", err)
+				os.Exit(1)
+			}
+			var metadata snapshot.Metadata
+			client, metadata, err = snapshot.Load(file)
+			file.Close()
+			if err != nil {
+				color.Red("Error loading snapshot: %v", err)
+				os.Exit(1)
+			}
+			// objects are checked as they were when the snapshot was taken
+			analyzedAt = metadata.CreatedAt
+		default:
+			client, err = kubernetes.DefaultLoader.Client()
+			if err != nil {
+				color.Red("Error initialising kubernetes client: %v", err)
+				os.Exit(1)
+			}
+		}
 		// Analysis configuration
 		config := &analyzer.AnalysisConfiguration{
-			Namespace: namespace,
-			NoCache:   nocache,
-			Explain:   explain,
+			Namespace:         namespace,
+			NoCache:           nocache,
+			Explain:           explain,
+			Language:          language,
+			MaxConcurrency:    concurrency,
+			WithLogs:          withLogs,
+			LogLines:          logLines,
+			Anonymize:         anonymize,
+			Selector:          selector,
+			ExcludeNamespaces: excludeNs,
+			Now:               analyzedAt,
+		}
+		if err := config.ValidateSelector(); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
 		}
 
-		var analysisResults *[]analyzer.Analysis = &[]analyzer.Analysis{}
-		if err := analyzer.RunAnalysis(ctx, filters, config, client,
-			aiClient, analysisResults); err != nil {
+		if explain {
+			aiCache, err := cache.NewFromConfig()
+			if err != nil {
+				color.Red("Error opening cache: %v", err)
+				os.Exit(1)
+			}
+			config.Cache = aiCache
+		}
+
+		if dir := viper.GetString("prompt_templates_dir"); dir != "" {
+			config.PromptTemplates = ai.NewPromptTemplates()
+			if err := config.PromptTemplates.LoadDir(dir); err != nil {
+				color.Red("Error loading prompt templates: %v", err)
+				os.Exit(1)
+			}
+		}
+
+		ignoreRules, err := analyzer.LoadIgnoreRules()
+		if err != nil {
 			color.Red("Error: %v", err)
 			os.Exit(1)
 		}
 
-		if len(*analysisResults) == 0 {
-			color.Green("{ \"status\": \"OK\" }")
-			os.Exit(0)
+		if watch {
+			watchAnalysis(ctx, severity, ignoreRules, config, client, aiClient)
+			return
 		}
-		var bar = progressbar.Default(int64(len(*analysisResults)))
-		if !explain {
-			bar.Clear()
+
+		var analysisResults *[]analyzer.Analysis = &[]analyzer.Analysis{}
+		// failing analyzers and clusters are reported, the results of the
+		// others are still printed
+		var analysisErr error
+		if len(contexts) != 0 {
+			loaders := make([]*kubernetes.Loader, len(contexts))
+			for i, name := range contexts {
+				loaders[i] = &kubernetes.Loader{Context: name, Kubeconfig: kubernetes.DefaultLoader.Kubeconfig}
+			}
+			analysisErr = analyzer.RunClusterAnalysis(ctx, filters, config, loaders,
+				aiClient, analysisResults)
+		} else {
+			analysisErr = analyzer.RunAnalysis(ctx, filters, config, client,
+				aiClient, analysisResults)
+		}
+		// the json-v2 output reports the errors itself
+		if analysisErr != nil && output != "json-v2" {
+			color.Red("Error: %v", analysisErr)
 		}
-		var printOutput []analyzer.Analysis
 
-		for _, analysis := range *analysisResults {
+		*analysisResults = analyzer.FilterSeverity(*analysisResults, severity)
+		// known and accepted failures are left out
+		var suppressed int
+		*analysisResults, suppressed = analyzer.ApplyIgnoreRules(*analysisResults, ignoreRules, time.Now())
 
-			if explain {
-				parsedText, err := analyzer.ParseViaAI(ctx, config, aiClient, analysis.Error)
-				if err != nil {
-					// Check for exhaustion
-					if strings.Contains(err.Error(), "status code: 429") {
-						color.Red("Exhausted API quota. Please try again later")
-						os.Exit(1)
-					}
-					color.Red("Error: %v", err)
-					continue
-				}
-				analysis.Details = parsedText
+		// a run where analyzers failed is not reported as healthy
+		if len(*analysisResults) == 0 && output != "json-v2" && analysisErr == nil {
+			color.Green("{ \"status\": \"OK\" }")
+			printSuppressed(suppressed)
+			os.Exit(0)
+		}
+		if explain && len(*analysisResults) > 0 {
+			var bar = progressbar.Default(int64(len(*analysisResults)))
+			err := analyzer.ExplainAnalysis(ctx, config, aiClient, *analysisResults, func() {
 				bar.Add(1)
+			})
+			if ai.IsRateLimited(err) {
+				color.Red("Exhausted API quota. Please try again later")
+				os.Exit(1)
+			}
+			if err != nil {
+				color.Red("Error: %v", err)
 			}
-			printOutput = append(printOutput, analysis)
 		}
 
 		// print results
-		for n, analysis := range printOutput {
-
-			switch output {
-			case "json":
-				analysis.Error = analysis.Error[0:]
+		switch output {
+		case "json":
+			for _, analysis := range *analysisResults {
 				j, err := json.Marshal(analysis)
 				if err != nil {
 					color.Red("Error: %v", err)
 					os.Exit(1)
 				}
 				fmt.Println(string(j))
-			default:
-				fmt.Printf("%s %s(%s)\n", color.CyanString("%d", n),
-					color.YellowString(analysis.Name), color.CyanString(analysis.ParentObject))
-				for _, err := range analysis.Error {
-					fmt.Printf("- %s %s\n", color.RedString("Error:"), color.RedString(err))
+			}
+		case "json-v2":
+			analysisOutput := analyzer.NewOutput(*analysisResults, analysisErr)
+			analysisOutput.Suppressed = suppressed
+			j, err := json.Marshal(analysisOutput)
+			if err != nil {
+				color.Red("Error: %v", err)
+				os.Exit(1)
+			}
+			fmt.Println(string(j))
+		default:
+			var cluster string
+			for n, analysis := range *analysisResults {
+				// results are sorted by cluster first
+				if analysis.Cluster != cluster {
+					cluster = analysis.Cluster
+					fmt.Printf("%s %s\n\n", color.MagentaString("Cluster:"), cluster)
 				}
-				fmt.Println(color.GreenString(analysis.Details + "\n"))
+				printAnalysis(color.CyanString("%d", n), analysis)
 			}
+			printSuppressed(suppressed)
+		}
+
+		// the partial results are printed, but the run failed
+		if analysisErr != nil {
+			os.Exit(1)
 		}
 	},
 }
 
+// printSuppressed prints the number of results suppressed by ignore rules
+func printSuppressed(suppressed int) {
+	if suppressed > 0 {
+		fmt.Println(color.HiBlackString("%d result(s) suppressed by ignore rules, see k8sgpt ignore list", suppressed))
+	}
+}
+
+// printAnalysis prints an analysis as text, its first line starting with
+// prefix
+func printAnalysis(prefix string, analysis analyzer.Analysis) {
+	fmt.Printf("%s %s %s(%s)\n", prefix, analysis.ID,
+		color.YellowString(analysis.Name), color.CyanString(analysis.ParentObject))
+	for _, failure := range analysis.Error {
+		severityColor := severityColors[failure.Severity]
+		fmt.Printf("- %s %s\n", severityColor.Sprint(severityLabels[failure.Severity]),
+			severityColor.Sprint(failure.Text))
+	}
+	for _, log := range analysis.Logs {
+		fmt.Println(color.HiBlackString(log.String()))
+	}
+	fmt.Println(color.GreenString(analysis.Details + "\n"))
+}
+
 func init() {
 
 	// namespace flag
 	AnalyzeCmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace to analyze")
+	// selector flags
+	AnalyzeCmd.Flags().StringVar(&selector, "selector", "", "Label selector of the objects to analyze (e.g. app=checkout)")
+	AnalyzeCmd.Flags().StringSliceVar(&excludeNs, "exclude-namespaces", []string{}, "Namespaces not to analyze (e.g. kube-system,kube-public)")
 	// no cache flag
 	AnalyzeCmd.Flags().BoolVarP(&nocache, "no-cache", "c", false, "Do not use cached data")
 	// array of strings flag
 	AnalyzeCmd.Flags().StringSliceVarP(&filters, "filter", "f", []string{}, "Filter for these analyzers (e.g. Pod, PersistentVolumeClaim, Service, ReplicaSet)")
+	// max concurrency flag
+	AnalyzeCmd.Flags().IntVar(&concurrency, "max-concurrency", analyzer.DefaultMaxConcurrency, "Maximum number of analyzers or AI requests to run concurrently")
+	// minimum severity flag
+	AnalyzeCmd.Flags().StringVar(&minSeverity, "min-severity", "info", "Only report failures of at least this severity (critical, warning, info)")
+	// with logs flag
+	AnalyzeCmd.Flags().BoolVar(&withLogs, "with-logs", false, "Attach the logs of crashing containers to the results and AI prompts, logs may contain sensitive data")
+	AnalyzeCmd.Flags().Int64Var(&logLines, "log-lines", analyzer.DefaultLogLines, "Number of lines fetched from each container log with --with-logs")
+	// anonymize flag
+	AnalyzeCmd.Flags().BoolVar(&anonymize, "anonymize", false, "Mask object names, IPs, emails and secrets before sending them to the AI backend")
+	// watch flag
+	AnalyzeCmd.Flags().BoolVarP(&watch, "watch", "w", false, "Keep running and report findings as they appear, change and resolve")
+	AnalyzeCmd.Flags().StringVar(&metricsAddress, "metrics-address", "", "Address to serve Prometheus metrics on in watch mode, e.g. :9090")
+	// from snapshot flag
+	AnalyzeCmd.Flags().StringVar(&fromSnapshot, "from-snapshot", "", "Analyze a snapshot taken with k8sgpt snapshot instead of the cluster")
+	// multi-cluster flags
+	AnalyzeCmd.Flags().BoolVar(&allContexts, "all-contexts", false, "Analyze the clusters of all the kubeconfig contexts")
+	AnalyzeCmd.Flags().StringSliceVar(&contextNames, "contexts", []string{}, "Analyze the clusters of these kubeconfig contexts (e.g. staging,production)")
 	// explain flag
 	AnalyzeCmd.Flags().BoolVarP(&explain, "explain", "e", false, "Explain the problem to me")
 	// add flag for backend
-	AnalyzeCmd.Flags().StringVarP(&backend, "backend", "b", "openai", "Backend AI provider")
+	AnalyzeCmd.Flags().StringVarP(&backend, "backend", "b", "",
+		fmt.Sprintf("Backend AI provider (%s), defaults to the one set by k8sgpt auth", strings.Join(ai.ListBackends(), ", ")))
+	// add flags for the model and its sampling parameters
+	AnalyzeCmd.Flags().StringVarP(&model, "model", "m", "", "Model to use with the backend AI provider (e.g. gpt-4)")
+	AnalyzeCmd.Flags().Float32Var(&temperature, "temperature", 0, "Sampling temperature between 0 and 2, the backend default when unset")
+	AnalyzeCmd.Flags().IntVar(&maxTokens, "max-tokens", 0, "Maximum number of tokens in the AI response, 0 means no limit")
+	// add flags for the rate limits of the backend
+	AnalyzeCmd.Flags().IntVar(&rpm, "requests-per-minute", 0, "Maximum number of AI requests per minute, 0 means no limit")
+	AnalyzeCmd.Flags().IntVar(&tpm, "tokens-per-minute", 0, "Maximum number of prompt tokens sent to the AI backend per minute, 0 means no limit")
 	// output as json
-	AnalyzeCmd.Flags().StringVarP(&output, "output", "o", "text", "Output format (text, json)")
+	AnalyzeCmd.Flags().StringVarP(&output, "output", "o", "text", "Output format (text, json, json-v2)")
 	// add language options for output
 	AnalyzeCmd.Flags().StringVarP(&language, "language", "l", "english", "Languages to use for AI (e.g. 'English', 'Spanish', 'French', 'German', 'Italian', 'Portuguese', 'Dutch', 'Russian', 'Chinese', 'Japanese', 'Korean')")
 }
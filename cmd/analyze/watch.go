@@ -0,0 +1,88 @@
+package analyze
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/analyzer"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var watchEventColors = map[analyzer.WatchEventType]*color.Color{
+	analyzer.WatchAdded:    color.New(color.FgRed),
+	analyzer.WatchChanged:  color.New(color.FgYellow),
+	analyzer.WatchResolved: color.New(color.FgGreen),
+}
+
+// watchEvent is the JSON output of a watch event
+type watchEvent struct {
+	Event    analyzer.WatchEventType `json:"event"`
+	Time     time.Time               `json:"time"`
+	Analysis analyzer.Analysis       `json:"analysis"`
+}
+
+// watchAnalysis prints the findings as they appear, change and resolve
+// until interrupted
+func watchAnalysis(ctx context.Context, severity analyzer.Severity, ignoreRules []analyzer.IgnoreRule,
+	config *analyzer.AnalysisConfiguration, client *kubernetes.Client, aiClient ai.IAI) {
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if metricsAddress != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		srv := &http.Server{Addr: metricsAddress, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				color.Red("Error serving metrics: %v", err)
+				os.Exit(1)
+			}
+		}()
+		defer srv.Close()
+	}
+
+	options := analyzer.WatchOptions{
+		Filters:     filters,
+		MinSeverity: severity,
+		IgnoreRules: ignoreRules,
+	}
+	err := analyzer.Watch(ctx, options, config, client, aiClient, func(event analyzer.WatchEvent) {
+		if event.Type == analyzer.WatchError {
+			color.Red("Error: %v", event.Err)
+			return
+		}
+
+		switch output {
+		case "json", "json-v2":
+			j, err := json.Marshal(watchEvent{
+				Event:    event.Type,
+				Time:     time.Now(),
+				Analysis: event.Analysis,
+			})
+			if err != nil {
+				color.Red("Error: %v", err)
+				return
+			}
+			fmt.Println(string(j))
+		default:
+			prefix := fmt.Sprintf("%s %s", time.Now().Format(time.TimeOnly),
+				watchEventColors[event.Type].Sprint(event.Type))
+			printAnalysis(prefix, event.Analysis)
+		}
+	})
+	if err != nil {
+		color.Red("Error: %v", err)
+		os.Exit(1)
+	}
+}
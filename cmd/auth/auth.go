@@ -7,6 +7,7 @@ import (
 	"syscall"
 
 	"github.com/fatih/color"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"golang.org/x/term"
@@ -14,6 +15,8 @@ import (
 
 var (
 	backend string
+	baseURL string
+	model   string
 )
 
 // authCmd represents the auth command
@@ -26,7 +29,8 @@ var AuthCmd = &cobra.Command{
 		backendType := viper.GetString("backend_type")
 		if backendType == "" {
 			// Set the default backend
-			viper.Set("backend_type", "openai")
+			backendType = "openai"
+			viper.Set("backend_type", backendType)
 			if err := viper.WriteConfig(); err != nil {
 				color.Red("Error writing config file: %s", err.Error())
 				os.Exit(1)
@@ -38,25 +42,54 @@ var AuthCmd = &cobra.Command{
 			color.Green("Using %s as backend AI provider", backendType)
 		}
 
-		fmt.Printf("Enter %s Key: ", backendType)
-		bytePassword, err := term.ReadPassword(int(syscall.Stdin))
-		if err != nil {
-			color.Red("Error reading %s Key from stdin: %s", backendType,
-				err.Error())
+		aiBackend, ok := ai.GetBackend(backendType)
+		if !ok {
+			color.Red("Backend %s is not supported. Available backends: %s", backendType,
+				strings.Join(ai.ListBackends(), ", "))
 			os.Exit(1)
 		}
-		password := strings.TrimSpace(string(bytePassword))
+		// make an explicitly chosen backend the default one
+		if cmd.Flags().Changed("backend") {
+			viper.Set("backend_type", backendType)
+		}
 
-		viper.Set(fmt.Sprintf("%s_key", backendType), password)
+		if baseURL != "" {
+			viper.Set(fmt.Sprintf("%s_baseurl", backendType), baseURL)
+		}
+		if model != "" {
+			viper.Set(fmt.Sprintf("%s_model", backendType), model)
+		}
+
+		if aiBackend.RequiresToken {
+			fmt.Printf("Enter %s Key: ", backendType)
+			bytePassword, err := term.ReadPassword(int(syscall.Stdin))
+			if err != nil {
+				color.Red("Error reading %s Key from stdin: %s", backendType,
+					err.Error())
+				os.Exit(1)
+			}
+			password := strings.TrimSpace(string(bytePassword))
+
+			viper.Set(fmt.Sprintf("%s_key", backendType), password)
+		}
 		if err := viper.WriteConfig(); err != nil {
 			color.Red("Error writing config file: %s", err.Error())
 			os.Exit(1)
 		}
-		color.Green("key added")
+		if aiBackend.RequiresToken {
+			color.Green("key added")
+		} else {
+			color.Green("%s backend configured", backendType)
+		}
 	},
 }
 
 func init() {
 	// add flag for backend
-	AuthCmd.Flags().StringVarP(&backend, "backend", "b", "openai", "Backend AI provider")
+	AuthCmd.Flags().StringVarP(&backend, "backend", "b", "openai",
+		fmt.Sprintf("Backend AI provider (%s)", strings.Join(ai.ListBackends(), ", ")))
+	// add flag for the base URL of OpenAI compatible backends
+	AuthCmd.Flags().StringVarP(&baseURL, "baseurl", "u", "", "URL of the AI provider API (e.g. http://localhost:8080/v1 for localai)")
+	// add flag for the model
+	AuthCmd.Flags().StringVarP(&model, "model", "m", "", "Model to use with the AI provider")
 }
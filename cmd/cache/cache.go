@@ -0,0 +1,24 @@
+package cache
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var CacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the cache of AI responses",
+	Long: `The cache command allows you to inspect and clear the AI responses cached by analyze --explain.
+	Responses are stored in the user cache directory and expire after cache_ttl (default 168h).`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			cmd.Help()
+			return
+		}
+	},
+}
+
+func init() {
+	CacheCmd.AddCommand(listCmd)
+	CacheCmd.AddCommand(purgeCmd)
+	CacheCmd.AddCommand(statsCmd)
+}
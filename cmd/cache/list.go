@@ -0,0 +1,49 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/cache"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List cached AI responses",
+	Long:  `The list command displays the AI responses stored in the cache, most recent first.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		aiCache, err := cache.NewFromConfig()
+		if err != nil {
+			color.Red("Error opening cache: %v", err)
+			os.Exit(1)
+		}
+		entries, err := aiCache.List()
+		if err != nil {
+			color.Red("Error listing cache: %v", err)
+			os.Exit(1)
+		}
+		if len(entries) == 0 {
+			color.Yellow("Cache is empty")
+			return
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].CreatedAt.After(entries[j].CreatedAt)
+		})
+		for _, entry := range entries {
+			fmt.Printf("> %s %s/%s %s\n", color.GreenString(shortKey(entry.Key)),
+				entry.Backend, entry.Model, color.CyanString(entry.CreatedAt.Format("2006-01-02 15:04:05")))
+		}
+	},
+}
+
+// shortKey returns the first characters of a cache key, enough to tell
+// entries apart. Keys of hand-edited or foreign cache files may be shorter.
+func shortKey(key string) string {
+	if len(key) > 12 {
+		return key[:12]
+	}
+	return key
+}
@@ -0,0 +1,28 @@
+package cache
+
+import (
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/cache"
+	"github.com/spf13/cobra"
+)
+
+var purgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Remove all cached AI responses",
+	Long:  `The purge command removes every AI response stored in the cache, even corrupted ones.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		aiCache, err := cache.NewFromConfig()
+		if err != nil {
+			color.Red("Error opening cache: %v", err)
+			os.Exit(1)
+		}
+		removed, err := aiCache.Purge()
+		if err != nil {
+			color.Red("Error purging cache: %v", err)
+			os.Exit(1)
+		}
+		color.Green("%d cache entries removed", removed)
+	},
+}
@@ -0,0 +1,32 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/cache"
+	"github.com/spf13/cobra"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show cache statistics",
+	Long:  `The stats command displays the location, number of entries and size of the cache.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		aiCache, err := cache.NewFromConfig()
+		if err != nil {
+			color.Red("Error opening cache: %v", err)
+			os.Exit(1)
+		}
+		stats, err := aiCache.Stats()
+		if err != nil {
+			color.Red("Error reading cache: %v", err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s %s\n", color.YellowString("Location:"), stats.Location)
+		fmt.Printf("%s %d\n", color.YellowString("Entries:"), stats.Entries)
+		fmt.Printf("%s %d\n", color.YellowString("Expired:"), stats.Expired)
+		fmt.Printf("%s %d bytes\n", color.YellowString("Size:"), stats.Size)
+	},
+}
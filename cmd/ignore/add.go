@@ -0,0 +1,69 @@
+package ignore
+
+import (
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/analyzer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	kind          string
+	namespace     string
+	name          string
+	reason        string
+	expires       string
+	justification string
+)
+
+var addCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Adds an ignore rule.",
+	Long: `The add command adds a rule suppressing the failures matching all of its kind, namespace, name and reason.
+	The name is a glob matched against the object name, e.g. 'example-*'.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		rule := analyzer.IgnoreRule{
+			Kind:          kind,
+			Namespace:     namespace,
+			Name:          name,
+			Reason:        reason,
+			Expires:       expires,
+			Justification: justification,
+		}
+		if err := rule.Validate(); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+
+		rules, err := analyzer.LoadIgnoreRules()
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+
+		// Verify duplicate
+		for _, r := range rules {
+			if r.String() == rule.String() {
+				color.Red("Duplicate ignore rule found: %s", rule)
+				os.Exit(1)
+			}
+		}
+
+		if err := analyzer.SaveIgnoreRules(append(rules, rule)); err != nil {
+			color.Red("Error writing config file: %s", err.Error())
+			os.Exit(1)
+		}
+		color.Green("Ignore rule %s added", rule)
+	},
+}
+
+func init() {
+	addCmd.Flags().StringVar(&kind, "kind", "", "Kind of the objects to ignore (e.g. Service)")
+	addCmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace of the objects to ignore")
+	addCmd.Flags().StringVar(&name, "name", "", "Name of the objects to ignore, may contain wildcards (e.g. 'batch-*')")
+	addCmd.Flags().StringVar(&reason, "reason", "", "Reason of the failures to ignore (e.g. ServiceNoEndpoints)")
+	addCmd.Flags().StringVar(&expires, "expires", "", "Date after which the rule no longer applies (YYYY-MM-DD)")
+	addCmd.Flags().StringVar(&justification, "justification", "", "Why the failures are accepted")
+}
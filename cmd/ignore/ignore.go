@@ -0,0 +1,24 @@
+package ignore
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var IgnoreCmd = &cobra.Command{
+	Use:   "ignore",
+	Short: "Manage rules suppressing known and accepted failures",
+	Long: `The ignore command allows you to manage the rules suppressing failures that are known and accepted.
+	Rules match failures by kind, namespace, name and reason, and can expire.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			cmd.Help()
+			return
+		}
+	},
+}
+
+func init() {
+	IgnoreCmd.AddCommand(listCmd)
+	IgnoreCmd.AddCommand(addCmd)
+	IgnoreCmd.AddCommand(removeCmd)
+}
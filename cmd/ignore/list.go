@@ -0,0 +1,41 @@
+package ignore
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/analyzer"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List ignore rules",
+	Long:  `The list command displays the ignore rules with their index, used to remove them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		rules, err := analyzer.LoadIgnoreRules()
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+		if len(rules) == 0 {
+			fmt.Println("No ignore rules, add one with k8sgpt ignore add")
+			return
+		}
+
+		now := time.Now()
+		fmt.Printf(color.YellowString("Ignore rules: \n"))
+		for i, rule := range rules {
+			text := color.GreenString(rule.String())
+			if rule.Expired(now) {
+				text = color.RedString("%s (expired)", rule)
+			}
+			fmt.Printf("%d > %s\n", i, text)
+			if rule.Justification != "" {
+				fmt.Printf("    %s\n", rule.Justification)
+			}
+		}
+	},
+}
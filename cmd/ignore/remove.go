@@ -0,0 +1,58 @@
+package ignore
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/analyzer"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var removeCmd = &cobra.Command{
+	Use:   "remove [index(es)]",
+	Short: "Remove one or more ignore rules.",
+	Long:  `The remove command removes one or more ignore rules by the index shown by k8sgpt ignore list.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		inputIndexes := strings.Split(args[0], ",")
+
+		rules, err := analyzer.LoadIgnoreRules()
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+
+		// verify duplicate indexes example: k8sgpt ignore remove 0,0
+		uniqueIndexes, duplicatedIndexes := util.RemoveDuplicates(inputIndexes)
+		if len(duplicatedIndexes) != 0 {
+			color.Red("Duplicate indexes found: %s", strings.Join(duplicatedIndexes, ", "))
+			os.Exit(1)
+		}
+
+		indexes := make([]int, 0, len(uniqueIndexes))
+		for _, input := range uniqueIndexes {
+			index, err := strconv.Atoi(input)
+			if err != nil || index < 0 || index >= len(rules) {
+				color.Red("Ignore rule %s does not exist. Please use k8sgpt ignore list", input)
+				os.Exit(1)
+			}
+			indexes = append(indexes, index)
+		}
+
+		// remove from the end so that the other indexes stay valid
+		sort.Sort(sort.Reverse(sort.IntSlice(indexes)))
+		for _, index := range indexes {
+			rules = append(rules[:index], rules[index+1:]...)
+		}
+
+		if err := analyzer.SaveIgnoreRules(rules); err != nil {
+			color.Red("Error writing config file: %s", err.Error())
+			os.Exit(1)
+		}
+		color.Green("Ignore rule(s) %s removed", strings.Join(inputIndexes, ", "))
+	},
+}
@@ -4,11 +4,14 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/k8sgpt-ai/k8sgpt/cmd/cache"
 	"github.com/k8sgpt-ai/k8sgpt/cmd/filters"
 	"github.com/k8sgpt-ai/k8sgpt/cmd/generate"
+	"github.com/k8sgpt-ai/k8sgpt/cmd/ignore"
+	"github.com/k8sgpt-ai/k8sgpt/cmd/serve"
+	"github.com/k8sgpt-ai/k8sgpt/cmd/snapshot"
 	"k8s.io/client-go/util/homedir"
 
-	"github.com/fatih/color"
 	"github.com/k8sgpt-ai/k8sgpt/cmd/analyze"
 	"github.com/k8sgpt-ai/k8sgpt/cmd/auth"
 	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
@@ -17,10 +20,8 @@ import (
 )
 
 var (
-	cfgFile     string
-	kubecontext string
-	kubeconfig  string
-	version     string
+	cfgFile string
+	version string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -53,10 +54,14 @@ func init() {
 	rootCmd.AddCommand(auth.AuthCmd)
 	rootCmd.AddCommand(analyze.AnalyzeCmd)
 	rootCmd.AddCommand(filters.FiltersCmd)
+	rootCmd.AddCommand(ignore.IgnoreCmd)
+	rootCmd.AddCommand(cache.CacheCmd)
 	rootCmd.AddCommand(generate.GenerateCmd)
+	rootCmd.AddCommand(serve.ServeCmd)
+	rootCmd.AddCommand(snapshot.SnapshotCmd)
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.k8sgpt.yaml)")
-	rootCmd.PersistentFlags().StringVar(&kubecontext, "kubecontext", "", "Kubernetes context to use. Only required if out-of-cluster.")
-	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", kubeconfigPath, "Path to a kubeconfig. Only required if out-of-cluster.")
+	rootCmd.PersistentFlags().StringVar(&kubernetes.DefaultLoader.Context, "kubecontext", "", "Kubernetes context to use. Only required if out-of-cluster.")
+	rootCmd.PersistentFlags().StringVar(&kubernetes.DefaultLoader.Kubeconfig, "kubeconfig", kubeconfigPath, "Path to a kubeconfig. Only required if out-of-cluster.")
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
 	// rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
@@ -81,15 +86,6 @@ func initConfig() {
 		viper.SafeWriteConfig()
 	}
 
-	//Initialise the kubeconfig
-	kubernetesClient, err := kubernetes.NewClient(kubecontext, kubeconfig)
-	if err != nil {
-		color.Red("Error initialising kubernetes client: %v", err)
-		os.Exit(1)
-	}
-
-	viper.Set("kubernetesClient", kubernetesClient)
-
 	viper.AutomaticEnv() // read in environment variables that match
 
 	// If a config file is found, read it in.
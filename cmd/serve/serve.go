@@ -0,0 +1,105 @@
+package serve
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/fatih/color"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/analyzer"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/cache"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/server"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	address     string
+	backend     string
+	concurrency int
+	anonymize   bool
+)
+
+var ServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the analysis as a REST API",
+	Long: `The serve command starts an HTTP server exposing POST /v1/analyze, which
+returns the same JSON as analyze --output json-v2, GET /v1/filters and GET /healthz.
+The server has no authentication and reads the cluster with the credentials of the
+kubeconfig, it only listens on localhost by default. Put an authenticating proxy in
+front of it before listening on other interfaces.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		backendType := viper.GetString("backend_type")
+		// override the default backend if a flag is provided
+		if backend != "" {
+			backendType = backend
+		}
+
+		// explanations are only available once a backend is set
+		var aiClient ai.IAI
+		if backendType == "" {
+			color.Yellow("No backend set, explanations are disabled. Please run k8sgpt auth")
+		} else {
+			var err error
+			aiClient, err = ai.NewClient(backendType, ai.LoadBackendConfig(backendType))
+			if err != nil {
+				color.Red("Error: %v", err)
+				os.Exit(1)
+			}
+		}
+
+		aiCache, err := cache.NewFromConfig()
+		if err != nil {
+			color.Red("Error opening cache: %v", err)
+			os.Exit(1)
+		}
+		config := analyzer.AnalysisConfiguration{
+			MaxConcurrency: concurrency,
+			Cache:          aiCache,
+			Anonymize:      anonymize,
+		}
+		if dir := viper.GetString("prompt_templates_dir"); dir != "" {
+			config.PromptTemplates = ai.NewPromptTemplates()
+			if err := config.PromptTemplates.LoadDir(dir); err != nil {
+				color.Red("Error loading prompt templates: %v", err)
+				os.Exit(1)
+			}
+		}
+
+		client, err := kubernetes.DefaultLoader.Client()
+		if err != nil {
+			color.Red("Error initialising kubernetes client: %v", err)
+			os.Exit(1)
+		}
+
+		s := &server.Server{
+			Address:  address,
+			Config:   config,
+			Client:   client,
+			AIClient: aiClient,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		color.Green("Listening on %s", address)
+		if err := s.Serve(ctx); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	ServeCmd.Flags().StringVarP(&address, "address", "a", "127.0.0.1:8080", "Address to listen on, the API has no authentication")
+	ServeCmd.Flags().StringVarP(&backend, "backend", "b", "",
+		fmt.Sprintf("Backend AI provider (%s), defaults to the one set by k8sgpt auth", strings.Join(ai.ListBackends(), ", ")))
+	ServeCmd.Flags().IntVar(&concurrency, "max-concurrency", analyzer.DefaultMaxConcurrency, "Maximum number of analyzers or AI requests to run concurrently per request")
+	ServeCmd.Flags().BoolVar(&anonymize, "anonymize", false, "Mask object names, IPs, emails and secrets before sending them to the AI backend")
+}
@@ -0,0 +1,60 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/snapshot"
+	"github.com/spf13/cobra"
+)
+
+var (
+	namespace string
+	output    string
+)
+
+var SnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save the cluster resources for offline analysis",
+	Long: `The snapshot command saves the resources read by the analyzers into a
+tarball, which can be analyzed without access to the cluster with
+k8sgpt analyze --from-snapshot. The data and annotations of secrets are
+not saved, and secrets are skipped when listing them is forbidden.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client, err := kubernetes.DefaultLoader.Client()
+		if err != nil {
+			color.Red("Error initialising kubernetes client: %v", err)
+			os.Exit(1)
+		}
+
+		if output == "" {
+			output = fmt.Sprintf("k8sgpt-snapshot-%s.tgz", time.Now().Format("20060102-150405"))
+		}
+		file, err := os.Create(output)
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+		defer file.Close()
+
+		metadata, err := snapshot.Create(context.Background(), client, namespace, file)
+		if err != nil {
+			color.Red("Error creating snapshot: %v", err)
+			os.Remove(output)
+			os.Exit(1)
+		}
+		for _, skipped := range metadata.Skipped {
+			color.Yellow("Listing %s is forbidden, they are not saved", skipped)
+		}
+		color.Green("Snapshot saved to %s", output)
+	},
+}
+
+func init() {
+	SnapshotCmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace to save, all namespaces by default")
+	SnapshotCmd.Flags().StringVarP(&output, "output", "o", "", "File to write the snapshot to, defaults to k8sgpt-snapshot-<time>.tgz")
+}
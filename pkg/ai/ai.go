@@ -3,46 +3,98 @@ package ai
 import (
 	"context"
 	"errors"
-	"fmt"
+	"math"
 
 	"github.com/sashabaranov/go-openai"
 )
 
-const (
-	default_prompt = "Simplify the following Kubernetes error message and provide a solution in %s: %s"
-	prompt_a       = "Read the following input %s and provide possible scenarios for remediation in %s"
-	prompt_b       = "Considering the following input from the Kubernetes resource %s and the error message %s, provide possible scenarios for remediation in %s"
-	prompt_c       = "Reading the following %s error message and it's accompanying log message %s, how would you simplify this message?"
-)
+func init() {
+	Register(Backend{
+		Name:          "openai",
+		RequiresToken: true,
+		New:           func() IAI { return &OpenAIClient{} },
+	})
+}
 
 type OpenAIClient struct {
-	client   *openai.Client
-	language string
+	client      *openai.Client
+	model       string
+	temperature *float32
+	maxTokens   int
 }
 
-func (c *OpenAIClient) Configure(token string, language string) error {
-	client := openai.NewClient(token)
+func (c *OpenAIClient) Configure(config BackendConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	clientConfig := openai.DefaultConfig(config.Token)
+	if config.BaseURL != "" {
+		clientConfig.BaseURL = config.BaseURL
+	}
+	client := openai.NewClientWithConfig(clientConfig)
 	if client == nil {
 		return errors.New("error creating OpenAI client")
 	}
-	c.language = language
 	c.client = client
+	c.model = config.Model
+	if c.model == "" {
+		c.model = openai.GPT3Dot5Turbo
+	}
+	c.temperature = config.Temperature
+	c.maxTokens = config.MaxTokens
 	return nil
 }
 
 func (c *OpenAIClient) GetCompletion(ctx context.Context, prompt string) (string, error) {
 	// Create a completion request
 	resp, err := c.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
-		Model: openai.GPT3Dot5Turbo,
+		Model:       c.model,
+		Temperature: requestTemperature(c.temperature),
+		MaxTokens:   c.maxTokens,
 		Messages: []openai.ChatCompletionMessage{
 			{
 				Role:    "user",
-				Content: fmt.Sprintf(default_prompt, c.language, prompt),
+				Content: prompt,
 			},
 		},
 	})
 	if err != nil {
-		return "", err
+		return "", toCompletionError(err)
 	}
 	return resp.Choices[0].Message.Content, nil
 }
+
+func (c *OpenAIClient) GetName() string {
+	return "openai"
+}
+
+func (c *OpenAIClient) GetModel() string {
+	return c.model
+}
+
+// requestTemperature returns the temperature of a completion request. The
+// request omits a zero temperature, which the API replaces with its default,
+// so a temperature of 0 is sent as the smallest positive float32.
+func requestTemperature(temperature *float32) float32 {
+	if temperature == nil {
+		return 0
+	}
+	if *temperature == 0 {
+		return math.SmallestNonzeroFloat32
+	}
+	return *temperature
+}
+
+// toCompletionError converts the errors of the OpenAI API into a
+// CompletionError carrying the status code
+func toCompletionError(err error) error {
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		return &CompletionError{StatusCode: apiErr.StatusCode, Err: err}
+	}
+	var requestErr *openai.RequestError
+	if errors.As(err, &requestErr) {
+		return &CompletionError{StatusCode: requestErr.StatusCode, Err: err}
+	}
+	return err
+}
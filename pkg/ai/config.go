@@ -0,0 +1,25 @@
+package ai
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// LoadBackendConfig reads the settings stored by k8sgpt auth for a backend
+func LoadBackendConfig(backend string) BackendConfig {
+	config := BackendConfig{
+		Token:             viper.GetString(fmt.Sprintf("%s_key", backend)),
+		BaseURL:           viper.GetString(fmt.Sprintf("%s_baseurl", backend)),
+		Model:             viper.GetString(fmt.Sprintf("%s_model", backend)),
+		MaxTokens:         viper.GetInt(fmt.Sprintf("%s_max_tokens", backend)),
+		RequestsPerMinute: viper.GetInt(fmt.Sprintf("%s_requests_per_minute", backend)),
+		TokensPerMinute:   viper.GetInt(fmt.Sprintf("%s_tokens_per_minute", backend)),
+	}
+	// a temperature of 0 is valid, so it is only left unset when missing
+	if key := fmt.Sprintf("%s_temperature", backend); viper.IsSet(key) {
+		temperature := float32(viper.GetFloat64(key))
+		config.Temperature = &temperature
+	}
+	return config
+}
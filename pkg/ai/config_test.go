@@ -0,0 +1,71 @@
+package ai
+
+import (
+	"math"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/spf13/viper"
+)
+
+func TestLoadBackendConfigTemperature(t *testing.T) {
+
+	viper.Reset()
+	defer viper.Reset()
+
+	// an unset temperature uses the backend default
+	config := LoadBackendConfig("openai")
+	assert.Equal(t, config.Temperature == nil, true)
+	assert.Equal(t, requestTemperature(config.Temperature), float32(0))
+
+	// a temperature of 0 is sent rather than omitted from the request
+	viper.Set("openai_temperature", 0)
+	config = LoadBackendConfig("openai")
+	assert.Equal(t, *config.Temperature, float32(0))
+	assert.Equal(t, requestTemperature(config.Temperature), float32(math.SmallestNonzeroFloat32))
+
+	viper.Set("openai_temperature", 0.2)
+	config = LoadBackendConfig("openai")
+	assert.Equal(t, requestTemperature(config.Temperature), float32(0.2))
+}
+
+func TestLoadBackendConfig(t *testing.T) {
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("openai_key", "key")
+	viper.Set("openai_model", "gpt-4")
+	viper.Set("openai_max_tokens", 512)
+	viper.Set("openai_requests_per_minute", 60)
+	viper.Set("localai_baseurl", "http://localhost:8080/v1")
+
+	tests := []struct {
+		name    string
+		backend string
+		config  BackendConfig
+	}{
+		{
+			name:    "openai",
+			backend: "openai",
+			config: BackendConfig{Token: "key", Model: "gpt-4", MaxTokens: 512,
+				RequestsPerMinute: 60},
+		},
+		{
+			// the settings of other backends are not used
+			name:    "localai",
+			backend: "localai",
+			config:  BackendConfig{BaseURL: "http://localhost:8080/v1"},
+		},
+		{
+			// unset settings fall back to the defaults of the backend
+			name:    "unconfigured backend",
+			backend: "unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, LoadBackendConfig(tt.backend), tt.config)
+		})
+	}
+}
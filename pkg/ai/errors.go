@@ -0,0 +1,38 @@
+package ai
+
+import (
+	"errors"
+	"net/http"
+)
+
+// CompletionError is returned by GetCompletion when the backend answers
+// with an error status code
+type CompletionError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *CompletionError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CompletionError) Unwrap() error {
+	return e.Err
+}
+
+// Retryable reports whether the same request may succeed later
+func (e *CompletionError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// IsRateLimited reports whether err was caused by the backend rate limiting
+// requests or the API quota being exhausted
+func IsRateLimited(err error) bool {
+	var completionErr *CompletionError
+	return errors.As(err, &completionErr) && completionErr.StatusCode == http.StatusTooManyRequests
+}
+
+func IsRetryable(err error) bool {
+	var completionErr *CompletionError
+	return errors.As(err, &completionErr) && completionErr.Retryable()
+}
@@ -1,8 +1,42 @@
 package ai
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 type IAI interface {
-	Configure(token string, language string) error
+	Configure(config BackendConfig) error
 	GetCompletion(ctx context.Context, prompt string) (string, error)
+	GetName() string
+	GetModel() string
+}
+
+// BackendConfig holds the settings used to configure an AI backend
+type BackendConfig struct {
+	Token   string
+	BaseURL string
+	Model   string
+	// Temperature controls the randomness of the response, nil uses the
+	// backend default
+	Temperature *float32
+	// MaxTokens caps the length of the response, zero means no limit
+	MaxTokens int
+	// RequestsPerMinute and TokensPerMinute are the rate limits of the
+	// account, zero means no limit
+	RequestsPerMinute int
+	TokensPerMinute   int
+}
+
+func (c BackendConfig) Validate() error {
+	if c.Temperature != nil && (*c.Temperature < 0 || *c.Temperature > 2) {
+		return fmt.Errorf("temperature must be between 0 and 2, got %v", *c.Temperature)
+	}
+	if c.MaxTokens < 0 {
+		return fmt.Errorf("max tokens must not be negative, got %d", c.MaxTokens)
+	}
+	if c.RequestsPerMinute < 0 || c.TokensPerMinute < 0 {
+		return fmt.Errorf("rate limits must not be negative")
+	}
+	return nil
 }
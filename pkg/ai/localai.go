@@ -0,0 +1,32 @@
+package ai
+
+import (
+	"errors"
+)
+
+func init() {
+	Register(Backend{
+		Name: "localai",
+		New:  func() IAI { return &LocalAIClient{} },
+	})
+}
+
+// LocalAIClient talks to any server implementing the OpenAI API, such as
+// LocalAI, so that k8sgpt can be used without access to the public API.
+type LocalAIClient struct {
+	OpenAIClient
+}
+
+func (c *LocalAIClient) Configure(config BackendConfig) error {
+	if config.BaseURL == "" {
+		return errors.New("localai backend requires a base URL, please run k8sgpt auth --backend localai --baseurl <url>")
+	}
+	if config.Model == "" {
+		return errors.New("localai backend requires a model, please run k8sgpt auth --backend localai --model <model>")
+	}
+	return c.OpenAIClient.Configure(config)
+}
+
+func (c *LocalAIClient) GetName() string {
+	return "localai"
+}
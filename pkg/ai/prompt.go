@@ -0,0 +1,105 @@
+package ai
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+const (
+	// DefaultPromptTemplate is the name of the template used for kinds
+	// without a template of their own
+	DefaultPromptTemplate = "default"
+	promptFileExtension   = ".tmpl"
+)
+
+var defaultPromptTemplates = map[string]string{
+	DefaultPromptTemplate: `Simplify the following Kubernetes error message and provide a solution in {{.Language}}: {{join .Errors " "}}`,
+	"Pod": `The Kubernetes pod {{.Name}}{{if .ParentObject}} owned by {{.ParentObject}}{{end}} is failing with the following errors: {{join .Errors " "}}
+{{- if .Logs}}
+The container logs end with:
+{{.Logs}}
+{{- end}}
+Explain in {{.Language}} why the containers are crashing or not starting, and provide a solution.`,
+	"Ingress": `The Kubernetes ingress {{.Name}} has the following networking problems: {{join .Errors " "}}
+Explain in {{.Language}} how traffic is affected, checking the ingress class, backend services and TLS secrets, and provide a solution.`,
+}
+
+var promptFuncs = template.FuncMap{
+	"join": strings.Join,
+}
+
+// PromptData holds the fields that prompt templates can reference
+type PromptData struct {
+	Kind         string
+	Name         string
+	ParentObject string
+	Errors       []string
+	Language     string
+	// Logs holds the container log excerpts, if they were fetched
+	Logs string
+}
+
+// PromptTemplates renders the prompt sent to the AI backend for an analysis,
+// using the template registered for its kind.
+type PromptTemplates struct {
+	templates map[string]*template.Template
+}
+
+// NewPromptTemplates returns the built-in prompt templates
+func NewPromptTemplates() *PromptTemplates {
+	p := &PromptTemplates{templates: map[string]*template.Template{}}
+	for kind, text := range defaultPromptTemplates {
+		// built-in templates are known to parse
+		if err := p.Add(kind, text); err != nil {
+			panic(err)
+		}
+	}
+	return p
+}
+
+// Add registers the template for a kind, replacing any existing one
+func (p *PromptTemplates) Add(kind string, text string) error {
+	tmpl, err := template.New(kind).Funcs(promptFuncs).Parse(text)
+	if err != nil {
+		return fmt.Errorf("error parsing prompt template %s: %w", kind, err)
+	}
+	p.templates[kind] = tmpl
+	return nil
+}
+
+// LoadDir overrides templates with the files found in dir. Each file is
+// named after the kind it applies to, e.g. Pod.tmpl, and default.tmpl
+// replaces the template used for all other kinds.
+func (p *PromptTemplates) LoadDir(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+promptFileExtension))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		text, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		kind := strings.TrimSuffix(filepath.Base(file), promptFileExtension)
+		if err := p.Add(kind, string(text)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PromptTemplates) Render(data PromptData) (string, error) {
+	tmpl, ok := p.templates[data.Kind]
+	if !ok {
+		tmpl = p.templates[DefaultPromptTemplate]
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("error rendering prompt template %s: %w", tmpl.Name(), err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,60 @@
+package ai
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestPromptTemplatesLoadDir(t *testing.T) {
+
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "Service.tmpl"),
+		[]byte(`{{.Kind}} {{.Name}} in {{.Language}}: {{join .Errors ", "}}`), 0644)
+	assert.Equal(t, err, nil)
+
+	templates := NewPromptTemplates()
+	err = templates.LoadDir(dir)
+	assert.Equal(t, err, nil)
+
+	prompt, err := templates.Render(PromptData{
+		Kind:     "Service",
+		Name:     "default/example",
+		Errors:   []string{"first", "second"},
+		Language: "english",
+	})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, prompt, "Service default/example in english: first, second")
+
+	// kinds without a template use the default one
+	prompt, err = templates.Render(PromptData{
+		Kind:     "ReplicaSet",
+		Errors:   []string{"first", "second"},
+		Language: "english",
+	})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, prompt, "Simplify the following Kubernetes error message and provide a solution in english: first second")
+}
+
+func TestPromptTemplatesPodLogs(t *testing.T) {
+
+	templates := NewPromptTemplates()
+	data := PromptData{
+		Kind:     "Pod",
+		Name:     "default/example",
+		Errors:   []string{"container app terminated with exit code 1: Error"},
+		Language: "english",
+	}
+
+	prompt, err := templates.Render(data)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, strings.Contains(prompt, "logs"), false)
+
+	data.Logs = "container app:\npanic: missing configuration"
+	prompt, err = templates.Render(data)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, strings.Contains(prompt, "The container logs end with:\ncontainer app:\npanic: missing configuration\nExplain"), true)
+}
@@ -0,0 +1,93 @@
+package ai
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter keeps the requests and tokens sent to a backend under a per
+// minute budget using token buckets
+type RateLimiter struct {
+	requests *bucket
+	tokens   *bucket
+}
+
+// NewRateLimiter returns a limiter allowing requestsPerMinute requests and
+// tokensPerMinute tokens, a limit of zero disables it
+func NewRateLimiter(requestsPerMinute int, tokensPerMinute int) *RateLimiter {
+	return &RateLimiter{
+		requests: newBucket(requestsPerMinute),
+		tokens:   newBucket(tokensPerMinute),
+	}
+}
+
+// Wait blocks until a request of the given number of tokens is allowed
+func (l *RateLimiter) Wait(ctx context.Context, tokens int) error {
+	now := time.Now()
+	delay := l.requests.reserve(now, 1)
+	if d := l.tokens.reserve(now, tokens); d > delay {
+		delay = d
+	}
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// EstimateTokens approximates the number of tokens of a prompt, OpenAI
+// models average about four characters per token
+func EstimateTokens(prompt string) int {
+	return len(prompt)/4 + 1
+}
+
+type bucket struct {
+	mutex    sync.Mutex
+	capacity float64
+	// rate is the number of tokens added per second
+	rate      float64
+	available float64
+	last      time.Time
+}
+
+func newBucket(perMinute int) *bucket {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &bucket{
+		capacity:  float64(perMinute),
+		rate:      float64(perMinute) / 60,
+		available: float64(perMinute),
+		last:      time.Now(),
+	}
+}
+
+// reserve takes n tokens from the bucket and returns how long the caller has
+// to wait until they are actually available
+func (b *bucket) reserve(now time.Time, n int) time.Duration {
+	if b == nil {
+		return 0
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.available += now.Sub(b.last).Seconds() * b.rate
+	if b.available > b.capacity {
+		b.available = b.capacity
+	}
+	b.last = now
+
+	// the bucket can go negative, later callers then wait for the debt
+	b.available -= float64(n)
+	if b.available >= 0 {
+		return 0
+	}
+	return time.Duration(-b.available / b.rate * float64(time.Second))
+}
@@ -0,0 +1,57 @@
+package ai
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Backend describes an AI provider that can be selected by name
+type Backend struct {
+	Name string
+	// RequiresToken is set when the provider cannot be used without an API key
+	RequiresToken bool
+	New           func() IAI
+}
+
+var backends = map[string]Backend{}
+
+// Register makes a backend available under its name. Registering the same
+// name twice replaces the previous backend.
+func Register(backend Backend) {
+	backends[backend.Name] = backend
+}
+
+func GetBackend(name string) (Backend, bool) {
+	backend, ok := backends[name]
+	return backend, ok
+}
+
+func ListBackends() []string {
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewClient returns a client for a backend configured with config, rate
+// limited and retrying as set in config
+func NewClient(name string, config BackendConfig) (IAI, error) {
+	backend, ok := GetBackend(name)
+	if !ok {
+		return nil, fmt.Errorf("backend %s is not supported, available backends: %s",
+			name, strings.Join(ListBackends(), ", "))
+	}
+	if backend.RequiresToken && config.Token == "" {
+		return nil, fmt.Errorf("no %s key set, please run k8sgpt auth", name)
+	}
+
+	client := backend.New()
+	if err := client.Configure(config); err != nil {
+		return nil, err
+	}
+	return NewThrottledClient(client,
+		NewRateLimiter(config.RequestsPerMinute, config.TokensPerMinute), DefaultRetryPolicy), nil
+}
@@ -0,0 +1,121 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestGetBackend(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		ok            bool
+		requiresToken bool
+	}{
+		{name: "openai", ok: true, requiresToken: true},
+		{name: "localai", ok: true},
+		{name: "unknown"},
+		{name: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, ok := GetBackend(tt.name)
+			assert.Equal(t, ok, tt.ok)
+			if ok {
+				assert.Equal(t, backend.Name, tt.name)
+				assert.Equal(t, backend.RequiresToken, tt.requiresToken)
+				assert.Equal(t, backend.New().GetName(), tt.name)
+			}
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+
+	temperature := float32(3)
+
+	tests := []struct {
+		name    string
+		backend string
+		config  BackendConfig
+		err     string
+	}{
+		{
+			name:    "unknown backend",
+			backend: "unknown",
+			err:     "backend unknown is not supported, available backends: localai, openai",
+		},
+		{
+			name:    "openai without key",
+			backend: "openai",
+			err:     "no openai key set",
+		},
+		{
+			name:    "openai",
+			backend: "openai",
+			config:  BackendConfig{Token: "key"},
+		},
+		{
+			name:    "localai without base URL",
+			backend: "localai",
+			config:  BackendConfig{Model: "ggml-gpt4all-j"},
+			err:     "localai backend requires a base URL",
+		},
+		{
+			name:    "localai without model",
+			backend: "localai",
+			config:  BackendConfig{BaseURL: "http://localhost:8080/v1"},
+			err:     "localai backend requires a model",
+		},
+		{
+			name:    "localai",
+			backend: "localai",
+			config:  BackendConfig{BaseURL: "http://localhost:8080/v1", Model: "ggml-gpt4all-j"},
+		},
+		{
+			name:    "localai with invalid settings",
+			backend: "localai",
+			config: BackendConfig{BaseURL: "http://localhost:8080/v1", Model: "ggml-gpt4all-j",
+				Temperature: &temperature},
+			err: "temperature must be between 0 and 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(tt.backend, tt.config)
+			if tt.err != "" {
+				assert.Equal(t, err != nil && strings.HasPrefix(err.Error(), tt.err), true)
+				return
+			}
+			assert.Equal(t, err, nil)
+			assert.Equal(t, client.GetName(), tt.backend)
+		})
+	}
+}
+
+func TestBackendConfigValidate(t *testing.T) {
+
+	zero, high := float32(0), float32(2.5)
+
+	tests := []struct {
+		name   string
+		config BackendConfig
+		valid  bool
+	}{
+		{name: "defaults", valid: true},
+		{name: "zero temperature", config: BackendConfig{Temperature: &zero}, valid: true},
+		{name: "temperature too high", config: BackendConfig{Temperature: &high}},
+		{name: "negative max tokens", config: BackendConfig{MaxTokens: -1}},
+		{name: "negative rate limit", config: BackendConfig{RequestsPerMinute: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.config.Validate() == nil, tt.valid)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package ai
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy configures the exponential backoff used when the backend
+// answers with a retryable error
+type RetryPolicy struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:     5,
+	InitialBackoff: time.Second,
+	MaxBackoff:     time.Minute,
+}
+
+// backoff returns the delay before the given retry, doubling each attempt
+// with a random jitter so that concurrent requests do not retry together
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.InitialBackoff << retry
+	if delay > p.MaxBackoff || delay <= 0 {
+		delay = p.MaxBackoff
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// ThrottledClient wraps a client to rate limit its requests and retry those
+// failing with a retryable error
+type ThrottledClient struct {
+	IAI
+	limiter *RateLimiter
+	retry   RetryPolicy
+}
+
+func NewThrottledClient(client IAI, limiter *RateLimiter, retry RetryPolicy) *ThrottledClient {
+	return &ThrottledClient{
+		IAI:     client,
+		limiter: limiter,
+		retry:   retry,
+	}
+}
+
+func (c *ThrottledClient) GetCompletion(ctx context.Context, prompt string) (string, error) {
+	for retry := 0; ; retry++ {
+		if err := c.limiter.Wait(ctx, EstimateTokens(prompt)); err != nil {
+			return "", err
+		}
+		response, err := c.IAI.GetCompletion(ctx, prompt)
+		if err == nil || !IsRetryable(err) || retry >= c.retry.MaxRetries {
+			return response, err
+		}
+
+		timer := time.NewTimer(c.retry.backoff(retry))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return "", ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+)
+
+type fakeClient struct {
+	errs  []error
+	calls int
+}
+
+func (c *fakeClient) Configure(config BackendConfig) error { return nil }
+func (c *fakeClient) GetName() string                      { return "fake" }
+func (c *fakeClient) GetModel() string                     { return "fake" }
+
+func (c *fakeClient) GetCompletion(ctx context.Context, prompt string) (string, error) {
+	c.calls++
+	if len(c.errs) >= c.calls {
+		return "", c.errs[c.calls-1]
+	}
+	return "response", nil
+}
+
+func TestThrottledClientRetries(t *testing.T) {
+
+	retry := RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	rateLimited := &CompletionError{StatusCode: http.StatusTooManyRequests, Err: errors.New("rate limited")}
+	unavailable := &CompletionError{StatusCode: http.StatusServiceUnavailable, Err: errors.New("unavailable")}
+
+	// retryable errors are retried until the request succeeds
+	fake := &fakeClient{errs: []error{rateLimited, unavailable}}
+	response, err := NewThrottledClient(fake, NewRateLimiter(0, 0), retry).GetCompletion(context.Background(), "prompt")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, response, "response")
+	assert.Equal(t, fake.calls, 3)
+
+	// and given up on after MaxRetries
+	fake = &fakeClient{errs: []error{rateLimited, rateLimited, rateLimited}}
+	_, err = NewThrottledClient(fake, NewRateLimiter(0, 0), retry).GetCompletion(context.Background(), "prompt")
+	assert.Equal(t, IsRateLimited(err), true)
+	assert.Equal(t, fake.calls, 3)
+
+	// other errors are returned immediately
+	badRequest := &CompletionError{StatusCode: http.StatusBadRequest, Err: errors.New("bad request")}
+	fake = &fakeClient{errs: []error{badRequest}}
+	_, err = NewThrottledClient(fake, NewRateLimiter(0, 0), retry).GetCompletion(context.Background(), "prompt")
+	assert.Equal(t, IsRetryable(err), false)
+	assert.Equal(t, fake.calls, 1)
+}
+
+func TestRateLimiter(t *testing.T) {
+
+	limiter := NewRateLimiter(60, 0)
+	start := time.Now()
+	// the bucket starts full
+	for i := 0; i < 60; i++ {
+		assert.Equal(t, limiter.Wait(context.Background(), 1), nil)
+	}
+	assert.Equal(t, time.Since(start) < time.Second, true)
+
+	// the next request has to wait for a token, about a second
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, limiter.Wait(ctx, 1), context.DeadlineExceeded)
+}
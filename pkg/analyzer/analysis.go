@@ -1,8 +1,17 @@
 package analyzer
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/cache"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
@@ -12,23 +21,170 @@ type AnalysisConfiguration struct {
 	Namespace string
 	NoCache   bool
 	Explain   bool
+	Language  string
+	// MaxConcurrency is the number of analyzers run at the same time
+	MaxConcurrency int
+	// PromptTemplates renders the prompt for each analysis, the built-in
+	// templates are used when nil
+	PromptTemplates *ai.PromptTemplates
+	// Cache stores the AI responses, responses are not cached when nil
+	Cache cache.ICache
+	// WithLogs attaches the logs of crashing containers to Pod findings,
+	// LogLines overrides DefaultLogLines when set
+	WithLogs bool
+	LogLines int64
+	// Anonymize masks object names, IPs, emails and secrets in the prompts
+	// sent to the AI backend
+	Anonymize bool
+	// Selector is a label selector restricting the analyzed objects, and the
+	// objects of ExcludeNamespaces are not analyzed
+	Selector          string
+	ExcludeNamespaces []string
+	// Now is the time the objects are checked at, such as the creation time
+	// of a snapshot, the current time is used when zero
+	Now time.Time
+}
+
+// now returns the time the time based checks of the analyzers compare to
+func (c *AnalysisConfiguration) now() time.Time {
+	if c.Now.IsZero() {
+		return time.Now()
+	}
+	return c.Now
 }
 
 type PreAnalysis struct {
 	Pod                      v1.Pod
-	FailureDetails           []string
+	FailureDetails           []Failure
 	ReplicaSet               appsv1.ReplicaSet
+	Deployment               appsv1.Deployment
+	StatefulSet              appsv1.StatefulSet
+	DaemonSet                appsv1.DaemonSet
+	Node                     v1.Node
+	Job                      batchv1.Job
+	CronJob                  batchv1.CronJob
 	PersistentVolumeClaim    v1.PersistentVolumeClaim
 	Endpoint                 v1.Endpoints
 	Ingress                  networkingv1.Ingress
 	HorizontalPodAutoscalers autoscalingv1.HorizontalPodAutoscaler
 	PodDisruptionBudget      policyv1.PodDisruptionBudget
+	Logs                     []ContainerLog
 }
 
+// Analysis is a problem found by an analyzer. ID is a fingerprint of the
+// problem that stays the same across runs and Severity is the highest
+// severity of its failures. Logs are only fetched when requested in the
+// configuration, and Cluster is only set when analyzing several clusters.
 type Analysis struct {
-	Kind         string   `json:"kind"`
-	Name         string   `json:"name"`
-	Error        []string `json:"error"`
-	Details      string   `json:"details"`
-	ParentObject string   `json:"parentObject"`
+	ID           string         `json:"id"`
+	Kind         string         `json:"kind"`
+	Name         string         `json:"name"`
+	Error        []Failure      `json:"error"`
+	Severity     Severity       `json:"severity"`
+	Details      string         `json:"details"`
+	ParentObject string         `json:"parentObject"`
+	Logs         []ContainerLog `json:"logs,omitempty"`
+	Cluster      string         `json:"cluster,omitempty"`
+}
+
+// Failure is a single problem found by an analyzer. Reason is a machine
+// readable code from reasons.go, Objects are the other objects involved in
+// the failure and Metadata holds any further detail.
+type Failure struct {
+	Text     string            `json:"text"`
+	Reason   string            `json:"reason"`
+	Severity Severity          `json:"severity"`
+	Objects  []ObjectReference `json:"objects,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+type ObjectReference struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// Fingerprint hashes the cluster, kind, name and errors of the analysis.
+// Errors are normalized so that their order and whitespace do not change the
+// result.
+func (a Analysis) Fingerprint() string {
+	errs := make([]string, 0, len(a.Error))
+	for _, err := range a.Errors() {
+		errs = append(errs, strings.Join(strings.Fields(err), " "))
+	}
+	sort.Strings(errs)
+
+	fields := []string{a.Kind, a.Name}
+	// the fingerprint of a single cluster analysis does not change
+	if a.Cluster != "" {
+		fields = append(fields, a.Cluster)
+	}
+
+	hash := sha256.New()
+	for _, s := range append(fields, errs...) {
+		hash.Write([]byte(s))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// SortAnalysis orders results by cluster, kind, namespace and name
+func SortAnalysis(analysisResults []Analysis) {
+	sort.SliceStable(analysisResults, func(i, j int) bool {
+		a, b := analysisResults[i], analysisResults[j]
+		if a.Cluster != b.Cluster {
+			return a.Cluster < b.Cluster
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+}
+
+// Errors returns the text of the failures
+func (a Analysis) Errors() []string {
+	errs := make([]string, 0, len(a.Error))
+	for _, failure := range a.Error {
+		errs = append(errs, failure.Text)
+	}
+	return errs
+}
+
+// ObjectNames returns the names of the objects involved in the analysis:
+// its namespace and name, its parent and the objects and containers of its
+// failures
+func (a Analysis) ObjectNames() []string {
+	names := strings.Split(a.Name, "/")
+	if a.ParentObject != "" {
+		parent := strings.Split(a.ParentObject, "/")
+		names = append(names, parent[len(parent)-1])
+	}
+	for _, failure := range a.Error {
+		for _, object := range failure.Objects {
+			names = append(names, object.Namespace, object.Name)
+		}
+		names = append(names, failure.Metadata["container"])
+	}
+	return names
+}
+
+// LogText returns the log excerpts as a single text
+func (a Analysis) LogText() string {
+	logs := make([]string, 0, len(a.Logs))
+	for _, log := range a.Logs {
+		logs = append(logs, log.String())
+	}
+	return strings.Join(logs, "\n")
+}
+
+// HighestSeverity returns the most severe of the failures
+func (a Analysis) HighestSeverity() Severity {
+	highest := SeverityInfo
+	for _, failure := range a.Error {
+		if failure.Severity > highest {
+			highest = failure.Severity
+		}
+	}
+	return highest
 }
@@ -2,12 +2,17 @@ package analyzer
 
 import (
 	"context"
-	"encoding/base64"
-	"strings"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/anonymizer"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/cache"
 	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/util"
 	"github.com/spf13/viper"
 )
 
@@ -17,90 +22,173 @@ var coreAnalyzerMap = map[string]IAnalyzer{
 	"PersistentVolumeClaim": PvcAnalyzer{},
 	"Service":               ServiceAnalyzer{},
 	"Ingress":               IngressAnalyzer{},
+	"Deployment":            DeploymentAnalyzer{},
+	"StatefulSet":           StatefulSetAnalyzer{},
+	"DaemonSet":             DaemonSetAnalyzer{},
+	"Node":                  NodeAnalyzer{},
 }
 
 var additionalAnalyzerMap = map[string]IAnalyzer{
 	"HorizontalPodAutoScaler": HpaAnalyzer{},
 	"PodDisruptionBudget":     PdbAnalyzer{},
+	"Job":                     JobAnalyzer{},
+	"CronJob":                 CronJobAnalyzer{},
 }
 
+// DefaultMaxConcurrency is the number of analyzers run at the same time
+// when AnalysisConfiguration.MaxConcurrency is not set
+const DefaultMaxConcurrency = 10
+
+// RunAnalysis runs the selected analyzers concurrently and appends their
+// results to analysisResults, sorted by kind and name and with their ID set. An analyzer failing
+// does not stop the others, all errors are returned together.
 func RunAnalysis(ctx context.Context, filters []string, config *AnalysisConfiguration,
 	client *kubernetes.Client,
 	aiClient ai.IAI, analysisResults *[]Analysis) error {
 
-	activeFilters := viper.GetStringSlice("active_filters")
-
 	analyzerMap := getAnalyzerMap()
+	selectedFilters := selectFilters(filters)
 
-	// if there are no filters selected and no active_filters then run all of them
-	if len(filters) == 0 && len(activeFilters) == 0 {
-		for _, analyzer := range analyzerMap {
-			if err := analyzer.RunAnalysis(ctx, config, client, aiClient, analysisResults); err != nil {
-				return err
-			}
+	maxConcurrency := config.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = DefaultMaxConcurrency
+	}
+	semaphore := make(chan struct{}, maxConcurrency)
+
+	var (
+		wg    sync.WaitGroup
+		mutex sync.Mutex
+		errs  []error
+	)
+	for _, filter := range selectedFilters {
+		analyzer, ok := analyzerMap[filter]
+		if !ok {
+			continue
 		}
-		return nil
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(filter string, analyzer IAnalyzer) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			var results []Analysis
+			start := time.Now()
+			err := analyzer.RunAnalysis(ctx, config, client, aiClient, &results)
+			analyzerDuration.WithLabelValues(filter).Observe(time.Since(start).Seconds())
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				analyzerErrors.WithLabelValues(filter).Inc()
+				errs = append(errs, fmt.Errorf("%s analyzer: %w", filter, err))
+				return
+			}
+			*analysisResults = append(*analysisResults, results...)
+		}(filter, analyzer)
 	}
+	wg.Wait()
 
-	// if the filters flag is specified
-	if len(filters) != 0 {
-		for _, filter := range filters {
-			if analyzer, ok := analyzerMap[filter]; ok {
-				if err := analyzer.RunAnalysis(ctx, config, client, aiClient, analysisResults); err != nil {
-					return err
-				}
-			}
-		}
-		return nil
+	for i := range *analysisResults {
+		(*analysisResults)[i].ID = (*analysisResults)[i].Fingerprint()
+		(*analysisResults)[i].Severity = (*analysisResults)[i].HighestSeverity()
 	}
+	SortAnalysis(*analysisResults)
+
+	return errors.Join(errs...)
+}
 
+// selectFilters returns the analyzers to run: the filters given, else the
+// active filters, else all of them
+func selectFilters(filters []string) []string {
+	activeFilters := viper.GetStringSlice("active_filters")
+
+	var selectedFilters []string
+	switch {
+	// if the filters flag is specified
+	case len(filters) != 0:
+		selectedFilters = filters
 	// use active_filters
-	for _, filter := range activeFilters {
-		if analyzer, ok := analyzerMap[filter]; ok {
-			if err := analyzer.RunAnalysis(ctx, config, client, aiClient, analysisResults); err != nil {
-				return err
-			}
+	case len(activeFilters) != 0:
+		selectedFilters = activeFilters
+	// if there are no filters selected and no active_filters then run all of them
+	default:
+		for filter := range getAnalyzerMap() {
+			selectedFilters = append(selectedFilters, filter)
 		}
 	}
-	return nil
+	selectedFilters, _ = util.RemoveDuplicates(selectedFilters)
+	return selectedFilters
 }
 
 func ParseViaAI(ctx context.Context, config *AnalysisConfiguration,
-	aiClient ai.IAI, prompt []string) (string, error) {
-	// parse the text with the AI backend
-	inputKey := strings.Join(prompt, " ")
+	aiClient ai.IAI, analysis Analysis) (string, error) {
+	templates := config.PromptTemplates
+	if templates == nil {
+		templates = ai.NewPromptTemplates()
+	}
+	inputKey, err := templates.Render(ai.PromptData{
+		Kind:         analysis.Kind,
+		Name:         analysis.Name,
+		ParentObject: analysis.ParentObject,
+		Errors:       analysis.Errors(),
+		Language:     config.Language,
+		Logs:         analysis.LogText(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// mask sensitive data, the cache then only holds masked responses
+	var masker *anonymizer.Anonymizer
+	if config.Anonymize {
+		masker = anonymizer.New()
+		masker.AddNames(analysis.ObjectNames()...)
+		inputKey = masker.Mask(inputKey)
+	}
+
 	// Check for cached data
-	sEnc := base64.StdEncoding.EncodeToString([]byte(inputKey))
-	// find in viper cache
-	if viper.IsSet(sEnc) && !config.NoCache {
-		// retrieve data from cache
-		response := viper.GetString(sEnc)
-		if response == "" {
-			color.Red("error retrieving cached data")
-			return "", nil
-		}
-		output, err := base64.StdEncoding.DecodeString(response)
+	cacheKey := cache.Key(aiClient.GetName(), aiClient.GetModel(), inputKey)
+	if config.Cache != nil && !config.NoCache {
+		entry, ok, err := config.Cache.Get(cacheKey)
 		if err != nil {
-			color.Red("error decoding cached data: %v", err)
-			return "", nil
+			color.Red("error retrieving cached data: %v", err)
+		} else if ok {
+			recordCacheLookup(true)
+			return unmask(masker, entry.Response), nil
+		} else {
+			recordCacheLookup(false)
 		}
-		return string(output), nil
 	}
 
+	// parse the text with the AI backend
+	backend, model := aiClient.GetName(), aiClient.GetModel()
+	aiRequests.WithLabelValues(backend, model).Inc()
+	start := time.Now()
 	response, err := aiClient.GetCompletion(ctx, inputKey)
+	aiRequestDuration.WithLabelValues(backend, model).Observe(time.Since(start).Seconds())
 	if err != nil {
-		color.Red("error getting completion: %v", err)
+		aiRequestErrors.WithLabelValues(backend, model).Inc()
 		return "", err
 	}
 
-	if !viper.IsSet(sEnc) {
-		viper.Set(sEnc, base64.StdEncoding.EncodeToString([]byte(response)))
-		if err := viper.WriteConfig(); err != nil {
-			color.Red("error writing config: %v", err)
-			return "", nil
+	if config.Cache != nil {
+		if err := config.Cache.Put(cache.Entry{
+			Key:      cacheKey,
+			Backend:  aiClient.GetName(),
+			Model:    aiClient.GetModel(),
+			Response: response,
+		}); err != nil {
+			color.Red("error writing cached data: %v", err)
 		}
 	}
-	return response, nil
+	return unmask(masker, response), nil
+}
+
+func unmask(masker *anonymizer.Anonymizer, response string) string {
+	if masker == nil {
+		return response
+	}
+	return masker.Unmask(response)
 }
 
 func ListFilters() ([]string, []string) {
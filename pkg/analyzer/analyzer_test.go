@@ -0,0 +1,355 @@
+package analyzer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/magiconair/properties/assert"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestRunAnalysis(t *testing.T) {
+
+	unschedulable := v1.PodStatus{
+		Phase: v1.PodPending,
+		Conditions: []v1.PodCondition{
+			{
+				Type:    v1.PodScheduled,
+				Reason:  "Unschedulable",
+				Message: "0/1 nodes are available",
+			},
+		},
+	}
+	clientset := fake.NewSimpleClientset(
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "default"},
+			Status:     unschedulable,
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "default"},
+			Status:     unschedulable,
+		},
+		&v1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1.ServiceSpec{
+				Selector: map[string]string{"app": "example"},
+			},
+		})
+
+	var analysisResults []Analysis
+	err := RunAnalysis(context.Background(), []string{"Service", "Pod", "Pod"},
+		&AnalysisConfiguration{
+			Namespace:      "default",
+			MaxConcurrency: 1,
+		},
+		&kubernetes.Client{
+			Client: clientset,
+		}, nil, &analysisResults)
+
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(analysisResults), 3)
+	assert.Equal(t, analysisResults[0].Name, "default/a")
+	assert.Equal(t, analysisResults[1].Name, "default/b")
+	assert.Equal(t, analysisResults[2].Kind, "Service")
+	assert.Equal(t, analysisResults[0].ID, analysisResults[0].Fingerprint())
+}
+
+func TestAnalysisFingerprint(t *testing.T) {
+
+	a := Analysis{Kind: "Pod", Name: "default/example", Error: []Failure{{Text: "first  error"}, {Text: "second error"}}}
+	b := Analysis{Kind: "Pod", Name: "default/example", Error: []Failure{{Text: "second error"}, {Text: " first error"}}}
+	c := Analysis{Kind: "Pod", Name: "default/other", Error: []Failure{{Text: "first error"}, {Text: "second error"}}}
+
+	assert.Equal(t, a.Fingerprint(), b.Fingerprint())
+	assert.Equal(t, a.Fingerprint() != c.Fingerprint(), true)
+	assert.Equal(t, len(a.Fingerprint()), 16)
+}
+
+func TestFilterSeverity(t *testing.T) {
+
+	analysisResults := []Analysis{
+		{Kind: "Pod", Name: "default/a", Error: []Failure{
+			{Text: "crash", Severity: SeverityCritical},
+			{Text: "note", Severity: SeverityInfo},
+		}},
+		{Kind: "PodDisruptionBudget", Name: "default/b", Error: []Failure{
+			{Text: "no pods", Severity: SeverityInfo},
+		}},
+	}
+
+	filtered := FilterSeverity(analysisResults, SeverityWarning)
+	assert.Equal(t, len(filtered), 1)
+	assert.Equal(t, len(filtered[0].Error), 1)
+	assert.Equal(t, filtered[0].Error[0].Text, "crash")
+
+	severity, err := ParseSeverity("Warning")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, severity, SeverityWarning)
+	_, err = ParseSeverity("fatal")
+	assert.Equal(t, err != nil, true)
+}
+
+// echoClient answers with the prompt it received
+type echoClient struct {
+	prompts []string
+}
+
+func (c *echoClient) Configure(config ai.BackendConfig) error { return nil }
+func (c *echoClient) GetName() string                         { return "echo" }
+func (c *echoClient) GetModel() string                        { return "echo" }
+
+func (c *echoClient) GetCompletion(ctx context.Context, prompt string) (string, error) {
+	c.prompts = append(c.prompts, prompt)
+	return prompt, nil
+}
+
+func TestParseViaAIAnonymize(t *testing.T) {
+
+	analysis := Analysis{
+		Kind: "Service",
+		Name: "payments/api",
+		Error: []Failure{{
+			Text:    "Service has no ready endpoints, pod payments/api-server-0 is not ready",
+			Objects: []ObjectReference{{Kind: "Pod", Namespace: "payments", Name: "api-server-0"}},
+		}},
+	}
+	client := &echoClient{}
+	response, err := ParseViaAI(context.Background(), &AnalysisConfiguration{
+		Language:  "english",
+		Anonymize: true,
+	}, client, analysis)
+	assert.Equal(t, err, nil)
+
+	// the backend only sees placeholders, the response is mapped back
+	assert.Equal(t, client.prompts[0], "Simplify the following Kubernetes error message and provide a solution in english: Service has no ready endpoints, pod <name-1>/<name-2> is not ready")
+	assert.Equal(t, response, "Simplify the following Kubernetes error message and provide a solution in english: Service has no ready endpoints, pod payments/api-server-0 is not ready")
+}
+
+func TestNewOutput(t *testing.T) {
+
+	output := NewOutput(nil, nil)
+	assert.Equal(t, output.Status, StatusOK)
+	assert.Equal(t, output.Results, []Analysis{})
+
+	output = NewOutput([]Analysis{{Kind: "Pod", Name: "default/example"}},
+		errors.Join(errors.New("Service analyzer: forbidden"), errors.New("Node analyzer: forbidden")))
+	assert.Equal(t, output.Status, StatusProblemDetected)
+	assert.Equal(t, output.Problems, 1)
+	assert.Equal(t, output.Errors, []string{"Service analyzer: forbidden", "Node analyzer: forbidden"})
+}
+
+func TestRunClusterAnalysis(t *testing.T) {
+
+	// each cluster is an API server serving a pod that cannot pull its image
+	pods, err := json.Marshal(v1.PodList{Items: []v1.Pod{{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "app", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ErrImagePull"}}},
+			},
+		},
+	}}})
+	assert.Equal(t, err, nil)
+	newServer := func() *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/v1/pods" {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(pods)
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	err = clientcmd.WriteToFile(clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"staging":    {Server: newServer().URL},
+			"production": {Server: newServer().URL},
+		},
+		Contexts: map[string]*clientcmdapi.Context{
+			"staging":    {Cluster: "staging"},
+			"production": {Cluster: "production"},
+		},
+	}, kubeconfig)
+	assert.Equal(t, err, nil)
+
+	loaders := []*kubernetes.Loader{
+		{Context: "staging", Kubeconfig: kubeconfig},
+		{Context: "production", Kubeconfig: kubeconfig},
+		// a cluster without kubeconfig fails without stopping the others
+		{Context: "unreachable", Kubeconfig: "/nonexistent/kubeconfig"},
+	}
+
+	var analysisResults []Analysis
+	err = RunClusterAnalysis(context.Background(), []string{"Pod"}, &AnalysisConfiguration{},
+		loaders, nil, &analysisResults)
+	assert.Equal(t, err != nil, true)
+	assert.Equal(t, strings.HasPrefix(err.Error(), "cluster unreachable: "), true)
+
+	assert.Equal(t, len(analysisResults), 2)
+	assert.Equal(t, analysisResults[0].Cluster, "production")
+	assert.Equal(t, analysisResults[1].Cluster, "staging")
+	// the same finding has a different ID in each cluster
+	assert.Equal(t, analysisResults[0].ID != analysisResults[1].ID, true)
+}
+
+func TestRunAnalysisSelectors(t *testing.T) {
+
+	unschedulable := v1.PodStatus{
+		Phase: v1.PodPending,
+		Conditions: []v1.PodCondition{
+			{
+				Type:    v1.PodScheduled,
+				Reason:  "Unschedulable",
+				Message: "0/1 nodes are available",
+			},
+		},
+	}
+	clientset := fake.NewSimpleClientset(
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "default",
+				Labels: map[string]string{"app": "checkout"}},
+			Status: unschedulable,
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "cart", Namespace: "default",
+				Labels: map[string]string{"app": "cart"}},
+			Status: unschedulable,
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system",
+				Labels: map[string]string{"app": "checkout"}},
+			Status: unschedulable,
+		})
+
+	var analysisResults []Analysis
+	config := &AnalysisConfiguration{
+		Selector:          "app=checkout",
+		ExcludeNamespaces: []string{"kube-system"},
+	}
+	err := RunAnalysis(context.Background(), []string{"Pod"}, config,
+		&kubernetes.Client{
+			Client: clientset,
+		}, nil, &analysisResults)
+
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(analysisResults), 1)
+	assert.Equal(t, analysisResults[0].Name, "default/checkout")
+
+	options := config.ListOptions()
+	assert.Equal(t, options.LabelSelector, "app=checkout")
+	assert.Equal(t, options.FieldSelector, "metadata.namespace!=kube-system")
+	assert.Equal(t, config.ClusterListOptions().FieldSelector, "")
+
+	config.Selector = "app in (checkout"
+	assert.Equal(t, config.ValidateSelector() != nil, true)
+}
+
+func TestApplyIgnoreRules(t *testing.T) {
+
+	analysisResults := []Analysis{
+		{
+			Kind: "Service",
+			Name: "default/example-a",
+			Error: []Failure{
+				{Reason: ReasonServiceNoEndpoints, Severity: SeverityWarning},
+			},
+			Severity: SeverityWarning,
+		},
+		{
+			Kind: "Service",
+			Name: "default/other",
+			Error: []Failure{
+				{Reason: ReasonServiceNoEndpoints, Severity: SeverityWarning},
+			},
+			Severity: SeverityWarning,
+		},
+		{
+			Kind: "Pod",
+			Name: "default/example-b",
+			Error: []Failure{
+				{Reason: ReasonContainerRestarting, Severity: SeverityWarning},
+				{Reason: ReasonCrashLoopBackOff, Severity: SeverityCritical},
+			},
+			Severity: SeverityCritical,
+		},
+		{
+			Kind: "Node",
+			Name: "node-1",
+			Error: []Failure{
+				{Reason: ReasonNodeCordoned, Severity: SeverityWarning},
+			},
+			Severity: SeverityWarning,
+		},
+	}
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	rules := []IgnoreRule{
+		{Kind: "service", Namespace: "default", Name: "example-*", Reason: ReasonServiceNoEndpoints},
+		{Kind: "Pod", Reason: ReasonCrashLoopBackOff, Expires: "2026-10-17"},
+		{Kind: "Node", Expires: "2026-10-16"},
+	}
+
+	filtered, suppressed := ApplyIgnoreRules(analysisResults, rules, now)
+	assert.Equal(t, suppressed, 1)
+	assert.Equal(t, len(filtered), 3)
+	assert.Equal(t, filtered[0].Name, "default/other")
+	assert.Equal(t, filtered[1].Name, "default/example-b")
+	assert.Equal(t, len(filtered[1].Error), 1)
+	assert.Equal(t, filtered[1].Severity, SeverityWarning)
+	assert.Equal(t, filtered[2].Name, "node-1")
+
+	assert.Equal(t, IgnoreRule{}.Validate() != nil, true)
+	assert.Equal(t, IgnoreRule{Name: "[a"}.Validate() != nil, true)
+	assert.Equal(t, IgnoreRule{Kind: "Pod", Expires: "31/12/2026"}.Validate() != nil, true)
+	assert.Equal(t, rules[0].Validate(), nil)
+}
+
+func TestRecordFindings(t *testing.T) {
+
+	RecordFindings([]Analysis{
+		{
+			Kind: "Pod",
+			Name: "default/a",
+			Error: []Failure{
+				{Reason: ReasonCrashLoopBackOff, Severity: SeverityCritical},
+				{Reason: ReasonContainerRestarting, Severity: SeverityWarning},
+			},
+		},
+		{
+			Kind:  "Pod",
+			Name:  "default/b",
+			Error: []Failure{{Reason: ReasonCrashLoopBackOff, Severity: SeverityCritical}},
+		},
+	})
+	expected := `
+# HELP k8sgpt_findings Number of failures found by the last analysis.
+# TYPE k8sgpt_findings gauge
+k8sgpt_findings{kind="Pod",namespace="default",reason="CrashLoopBackOff",severity="critical"} 2
+k8sgpt_findings{kind="Pod",namespace="default",reason="ContainerRestarting",severity="warning"} 1
+`
+	assert.Equal(t, testutil.CollectAndCompare(findings, strings.NewReader(expected)), nil)
+
+	// the findings of earlier analyses are removed
+	RecordFindings(nil)
+	assert.Equal(t, testutil.CollectAndCount(findings), 0)
+}
@@ -0,0 +1,60 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+)
+
+// RunClusterAnalysis runs RunAnalysis against the cluster of each loader
+// concurrently and sets the Cluster of the results to the loader context.
+// A cluster that cannot be reached does not stop the others, the errors of
+// all clusters are returned together.
+func RunClusterAnalysis(ctx context.Context, filters []string, config *AnalysisConfiguration,
+	loaders []*kubernetes.Loader, aiClient ai.IAI, analysisResults *[]Analysis) error {
+
+	maxConcurrency := config.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = DefaultMaxConcurrency
+	}
+	semaphore := make(chan struct{}, maxConcurrency)
+
+	var (
+		wg    sync.WaitGroup
+		mutex sync.Mutex
+		errs  []error
+	)
+	for _, loader := range loaders {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(loader *kubernetes.Loader) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			var results []Analysis
+			client, err := loader.Client()
+			if err == nil {
+				err = RunAnalysis(ctx, filters, config, client, aiClient, &results)
+			}
+			for i := range results {
+				results[i].Cluster = loader.Context
+				results[i].ID = results[i].Fingerprint()
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("cluster %s: %w", loader.Context, err))
+			}
+			*analysisResults = append(*analysisResults, results...)
+		}(loader)
+	}
+	wg.Wait()
+
+	SortAnalysis(*analysisResults)
+	return errors.Join(errs...)
+}
@@ -0,0 +1,110 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/util"
+	"github.com/robfig/cron/v3"
+)
+
+// DefaultMissedSchedules is the number of schedules a CronJob can go
+// without a successful run before it is reported
+const DefaultMissedSchedules = 3
+
+type CronJobAnalyzer struct {
+	// MissedSchedules overrides DefaultMissedSchedules when set
+	MissedSchedules int
+}
+
+func (c CronJobAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguration,
+	client *kubernetes.Client, aiClient ai.IAI, analysisResults *[]Analysis) error {
+
+	list, err := client.GetClient().BatchV1().CronJobs(config.Namespace).List(ctx, config.ListOptions())
+	if err != nil {
+		return err
+	}
+
+	missedSchedules := c.MissedSchedules
+	if missedSchedules == 0 {
+		missedSchedules = DefaultMissedSchedules
+	}
+
+	var preAnalysis = map[string]PreAnalysis{}
+
+	for _, cronJob := range list.Items {
+		if config.IsExcluded(cronJob.Namespace) {
+			continue
+		}
+		var failures []Failure
+
+		schedule := cronJob.Spec.Schedule
+		if cronJob.Spec.TimeZone != nil {
+			schedule = fmt.Sprintf("CRON_TZ=%s %s", *cronJob.Spec.TimeZone, schedule)
+		}
+		parsedSchedule, err := cron.ParseStandard(schedule)
+
+		switch {
+		case err != nil:
+			failures = append(failures, Failure{
+				Text:     fmt.Sprintf("CronJob has an invalid schedule %q: %v", cronJob.Spec.Schedule, err),
+				Reason:   ReasonCronJobInvalidSchedule,
+				Severity: SeverityCritical,
+			})
+		case cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend:
+			failures = append(failures, Failure{
+				Text:     "CronJob is suspended",
+				Reason:   ReasonCronJobSuspended,
+				Severity: SeverityInfo,
+			})
+		default:
+			// Check the CronJob succeeded within the last missedSchedules schedules
+			since := cronJob.CreationTimestamp.Time
+			if cronJob.Status.LastSuccessfulTime != nil {
+				since = cronJob.Status.LastSuccessfulTime.Time
+			}
+			deadline := since
+			for i := 0; i < missedSchedules; i++ {
+				deadline = parsedSchedule.Next(deadline)
+			}
+			if !deadline.IsZero() && deadline.Before(config.now()) {
+				text := fmt.Sprintf("CronJob has not succeeded in the last %d schedules", missedSchedules)
+				if cronJob.Status.LastSuccessfulTime != nil {
+					text = fmt.Sprintf("%s, last success at %s", text, since.Format(time.RFC3339))
+				}
+				failures = append(failures, Failure{
+					Text:     text,
+					Reason:   ReasonCronJobNotSucceeded,
+					Severity: SeverityWarning,
+					Metadata: map[string]string{
+						"schedule": cronJob.Spec.Schedule,
+					},
+				})
+			}
+		}
+
+		if len(failures) > 0 {
+			preAnalysis[fmt.Sprintf("%s/%s", cronJob.Namespace, cronJob.Name)] = PreAnalysis{
+				CronJob:        cronJob,
+				FailureDetails: failures,
+			}
+		}
+	}
+
+	for key, value := range preAnalysis {
+		var currentAnalysis = Analysis{
+			Kind:  "CronJob",
+			Name:  key,
+			Error: value.FailureDetails,
+		}
+
+		parent, _ := util.GetParent(client, value.CronJob.ObjectMeta)
+		currentAnalysis.ParentObject = parent
+		*analysisResults = append(*analysisResults, currentAnalysis)
+	}
+
+	return nil
+}
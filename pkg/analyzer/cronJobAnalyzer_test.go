@@ -0,0 +1,83 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/magiconair/properties/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCronJobAnalyzer(t *testing.T) {
+
+	suspend := true
+	recently := metav1.NewTime(time.Now().Add(-30 * time.Minute))
+	longAgo := metav1.NewTime(time.Now().Add(-5 * time.Hour))
+
+	tests := []struct {
+		name    string
+		spec    batchv1.CronJobSpec
+		status  batchv1.CronJobStatus
+		reasons []string
+	}{
+		{
+			name:   "succeeded recently",
+			spec:   batchv1.CronJobSpec{Schedule: "0 * * * *"},
+			status: batchv1.CronJobStatus{LastSuccessfulTime: &recently},
+		},
+		{
+			name:    "invalid schedule",
+			spec:    batchv1.CronJobSpec{Schedule: "every hour"},
+			reasons: []string{ReasonCronJobInvalidSchedule},
+		},
+		{
+			name:    "suspended",
+			spec:    batchv1.CronJobSpec{Schedule: "0 * * * *", Suspend: &suspend},
+			status:  batchv1.CronJobStatus{LastSuccessfulTime: &longAgo},
+			reasons: []string{ReasonCronJobSuspended},
+		},
+		{
+			name:    "not succeeded in the last schedules",
+			spec:    batchv1.CronJobSpec{Schedule: "0 * * * *"},
+			status:  batchv1.CronJobStatus{LastSuccessfulTime: &longAgo},
+			reasons: []string{ReasonCronJobNotSucceeded},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(&batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "example",
+					Namespace:         "default",
+					CreationTimestamp: longAgo,
+				},
+				Spec:   tt.spec,
+				Status: tt.status,
+			})
+
+			cronJobAnalyzer := CronJobAnalyzer{MissedSchedules: 3}
+			var analysisResults []Analysis
+			err := cronJobAnalyzer.RunAnalysis(context.Background(),
+				&AnalysisConfiguration{
+					Namespace: "default",
+				},
+				&kubernetes.Client{
+					Client: clientset,
+				}, nil, &analysisResults)
+			assert.Equal(t, err, nil)
+
+			var reasons []string
+			for _, analysis := range analysisResults {
+				for _, failure := range analysis.Error {
+					reasons = append(reasons, failure.Reason)
+				}
+			}
+			assert.Equal(t, reasons, tt.reasons)
+		})
+	}
+}
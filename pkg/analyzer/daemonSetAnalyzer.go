@@ -0,0 +1,205 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/util"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// daemonSetTolerations are added to every DaemonSet pod by the controller
+var daemonSetTolerations = []v1.Toleration{
+	{Key: v1.TaintNodeNotReady, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute},
+	{Key: v1.TaintNodeUnreachable, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute},
+	{Key: v1.TaintNodeDiskPressure, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+	{Key: v1.TaintNodeMemoryPressure, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+	{Key: v1.TaintNodePIDPressure, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+	{Key: v1.TaintNodeUnschedulable, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+}
+
+// daemonSetNodeGrace is how long a new node can run without a pod of a
+// DaemonSet before it is reported, pods take a while to start on new nodes
+const daemonSetNodeGrace = 5 * time.Minute
+
+type DaemonSetAnalyzer struct{}
+
+func (DaemonSetAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguration,
+	client *kubernetes.Client, aiClient ai.IAI, analysisResults *[]Analysis) error {
+
+	list, err := client.GetClient().AppsV1().DaemonSets(config.Namespace).List(ctx, config.ListOptions())
+	if err != nil {
+		return err
+	}
+	if len(list.Items) == 0 {
+		return nil
+	}
+
+	nodes, err := client.GetClient().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	var preAnalysis = map[string]PreAnalysis{}
+
+	for _, ds := range list.Items {
+		if config.IsExcluded(ds.Namespace) {
+			continue
+		}
+		// the texts do not include the pod counts, which change with every
+		// status update, so that the fingerprint of the failures is stable
+		var failures []Failure
+
+		if ds.Status.NumberMisscheduled > 0 {
+			failures = append(failures, Failure{
+				Text:     "DaemonSet has pods running on nodes where they should not run",
+				Reason:   ReasonDaemonSetMisscheduled,
+				Severity: SeverityWarning,
+				Metadata: map[string]string{
+					"numberMisscheduled": fmt.Sprint(ds.Status.NumberMisscheduled),
+				},
+			})
+		}
+
+		if ds.Status.DesiredNumberScheduled != ds.Status.NumberReady {
+			failures = append(failures, Failure{
+				Text:     "DaemonSet does not have all of its desired pods ready",
+				Reason:   ReasonDaemonSetNotReady,
+				Severity: SeverityWarning,
+				Metadata: map[string]string{
+					"numberReady":            fmt.Sprint(ds.Status.NumberReady),
+					"desiredNumberScheduled": fmt.Sprint(ds.Status.DesiredNumberScheduled),
+				},
+			})
+		}
+
+		// only look for the nodes missing a pod when the DaemonSet is short of
+		// pods, the nodes it does not select are left out on purpose
+		if ds.Status.CurrentNumberScheduled < ds.Status.DesiredNumberScheduled ||
+			ds.Status.NumberReady < ds.Status.DesiredNumberScheduled {
+			missing, err := findMissingDaemonSetPods(ctx, config, client, ds, nodes.Items)
+			if err != nil {
+				return err
+			}
+			failures = append(failures, missing...)
+		}
+
+		if len(failures) > 0 {
+			preAnalysis[fmt.Sprintf("%s/%s", ds.Namespace, ds.Name)] = PreAnalysis{
+				DaemonSet:      ds,
+				FailureDetails: failures,
+			}
+		}
+	}
+
+	for key, value := range preAnalysis {
+		var currentAnalysis = Analysis{
+			Kind:  "DaemonSet",
+			Name:  key,
+			Error: value.FailureDetails,
+		}
+
+		parent, _ := util.GetParent(client, value.DaemonSet.ObjectMeta)
+		currentAnalysis.ParentObject = parent
+		*analysisResults = append(*analysisResults, currentAnalysis)
+	}
+
+	return nil
+}
+
+// findMissingDaemonSetPods reports the nodes selected by the DaemonSet that
+// do not run one of its pods, naming the taint keeping it off the node
+func findMissingDaemonSetPods(ctx context.Context, config *AnalysisConfiguration, client *kubernetes.Client,
+	ds appsv1.DaemonSet, nodes []v1.Node) ([]Failure, error) {
+
+	// only the node selector is evaluated, node affinity would need the scheduler
+	if affinity := ds.Spec.Template.Spec.Affinity; affinity != nil && affinity.NodeAffinity != nil {
+		return nil, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := client.GetClient().CoreV1().Pods(ds.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	scheduled := map[string]bool{}
+	for _, pod := range pods.Items {
+		if metav1.IsControlledBy(&pod, &ds) {
+			scheduled[pod.Spec.NodeName] = true
+		}
+	}
+
+	var tolerations []v1.Toleration
+	tolerations = append(tolerations, ds.Spec.Template.Spec.Tolerations...)
+	tolerations = append(tolerations, daemonSetTolerations...)
+
+	var failures []Failure
+	for _, node := range nodes {
+		if scheduled[node.Name] || !matchesNodeSelector(node, ds.Spec.Template.Spec.NodeSelector) {
+			continue
+		}
+		if config.now().Sub(node.CreationTimestamp.Time) < daemonSetNodeGrace {
+			continue
+		}
+		if taint := findUntoleratedTaint(node.Spec.Taints, tolerations); taint != nil {
+			failures = append(failures, Failure{
+				Text: fmt.Sprintf("DaemonSet has no pod on node %s because it does not tolerate the taint %s",
+					node.Name, taint.ToString()),
+				Reason:   ReasonDaemonSetTaintNotTolerated,
+				Severity: SeverityInfo,
+				Objects:  []ObjectReference{{Kind: "Node", Name: node.Name}},
+				Metadata: map[string]string{
+					"taint": taint.ToString(),
+				},
+			})
+			continue
+		}
+		failures = append(failures, Failure{
+			Text:     fmt.Sprintf("DaemonSet has no pod on node %s", node.Name),
+			Reason:   ReasonDaemonSetPodMissing,
+			Severity: SeverityWarning,
+			Objects:  []ObjectReference{{Kind: "Node", Name: node.Name}},
+		})
+	}
+	return failures, nil
+}
+
+func matchesNodeSelector(node v1.Node, nodeSelector map[string]string) bool {
+	for k, v := range nodeSelector {
+		if node.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// findUntoleratedTaint returns the first taint preventing pods with the
+// given tolerations from being scheduled on a node
+func findUntoleratedTaint(taints []v1.Taint, tolerations []v1.Toleration) *v1.Taint {
+	for i, taint := range taints {
+		if taint.Effect != v1.TaintEffectNoSchedule && taint.Effect != v1.TaintEffectNoExecute {
+			continue
+		}
+		tolerated := false
+		for _, toleration := range tolerations {
+			if toleration.ToleratesTaint(&taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return &taints[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package analyzer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/magiconair/properties/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDaemonSetAnalyzer(t *testing.T) {
+
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example",
+			Namespace: "default",
+			UID:       "example-uid",
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "example"}},
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Tolerations: []v1.Toleration{
+						{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "monitoring", Effect: v1.TaintEffectNoSchedule},
+					},
+				},
+			},
+		},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: 3,
+			NumberReady:            1,
+		},
+	}
+	controller := true
+	// nodes joined long enough ago for the DaemonSet pods to have started
+	joined := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	clientset := fake.NewSimpleClientset(ds,
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "running", CreationTimestamp: joined}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "missing", CreationTimestamp: joined}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "joining", CreationTimestamp: metav1.Now()}},
+		&v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "tolerated", CreationTimestamp: joined},
+			Spec: v1.NodeSpec{Taints: []v1.Taint{
+				{Key: "dedicated", Value: "monitoring", Effect: v1.TaintEffectNoSchedule},
+				{Key: v1.TaintNodeUnschedulable, Effect: v1.TaintEffectNoSchedule},
+			}},
+		},
+		&v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "tainted", CreationTimestamp: joined},
+			Spec: v1.NodeSpec{Taints: []v1.Taint{
+				{Key: "gpu", Value: "true", Effect: v1.TaintEffectPreferNoSchedule},
+				{Key: "dedicated", Value: "database", Effect: v1.TaintEffectNoSchedule},
+			}},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "example-abcde",
+				Namespace: "default",
+				Labels:    map[string]string{"app": "example"},
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "DaemonSet", Name: "example", UID: "example-uid", Controller: &controller},
+				},
+			},
+			Spec: v1.PodSpec{NodeName: "running"},
+		})
+
+	daemonSetAnalyzer := DaemonSetAnalyzer{}
+	var analysisResults []Analysis
+	err := daemonSetAnalyzer.RunAnalysis(context.Background(),
+		&AnalysisConfiguration{
+			Namespace: "default",
+		},
+		&kubernetes.Client{
+			Client: clientset,
+		}, nil, &analysisResults)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(analysisResults), 1)
+
+	failures := map[string]Failure{}
+	for _, failure := range analysisResults[0].Error {
+		if len(failure.Objects) > 0 {
+			failures[failure.Objects[0].Name] = failure
+		} else {
+			failures[failure.Reason] = failure
+		}
+	}
+	assert.Equal(t, len(failures), 4)
+	assert.Equal(t, failures[ReasonDaemonSetNotReady].Severity, SeverityWarning)
+	// the counts are kept out of the text so that the fingerprint is stable
+	assert.Equal(t, failures[ReasonDaemonSetNotReady].Text, "DaemonSet does not have all of its desired pods ready")
+	assert.Equal(t, failures[ReasonDaemonSetNotReady].Metadata["numberReady"], "1")
+	assert.Equal(t, failures["missing"].Reason, ReasonDaemonSetPodMissing)
+	// tolerated taints do not explain the missing pod
+	assert.Equal(t, failures["tolerated"].Reason, ReasonDaemonSetPodMissing)
+	// the first NoSchedule taint the DaemonSet does not tolerate is named
+	assert.Equal(t, failures["tainted"].Reason, ReasonDaemonSetTaintNotTolerated)
+	assert.Equal(t, failures["tainted"].Severity, SeverityInfo)
+	assert.Equal(t, failures["tainted"].Metadata["taint"], "dedicated=database:NoSchedule")
+	assert.Equal(t, strings.Contains(failures["tainted"].Text, "dedicated=database:NoSchedule"), true)
+	// new nodes are given time to start the pod
+	for _, node := range []string{"running", "joining"} {
+		_, ok := failures[node]
+		assert.Equal(t, ok, false)
+	}
+
+	// a DaemonSet with all its desired pods ready is healthy, whatever the
+	// nodes it does not run on
+	ds.Status = appsv1.DaemonSetStatus{
+		DesiredNumberScheduled: 1,
+		CurrentNumberScheduled: 1,
+		NumberReady:            1,
+	}
+	_, err = clientset.AppsV1().DaemonSets("default").UpdateStatus(context.Background(), ds, metav1.UpdateOptions{})
+	assert.Equal(t, err, nil)
+	analysisResults = nil
+	err = daemonSetAnalyzer.RunAnalysis(context.Background(),
+		&AnalysisConfiguration{
+			Namespace: "default",
+		},
+		&kubernetes.Client{
+			Client: clientset,
+		}, nil, &analysisResults)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(analysisResults), 0)
+}
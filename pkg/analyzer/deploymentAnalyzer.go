@@ -0,0 +1,122 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/util"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// DefaultUnavailableThreshold is how long a deployment can run with fewer
+// available replicas than desired before it is reported
+const DefaultUnavailableThreshold = 10 * time.Minute
+
+type DeploymentAnalyzer struct {
+	// UnavailableThreshold overrides DefaultUnavailableThreshold when set
+	UnavailableThreshold time.Duration
+}
+
+func (d DeploymentAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguration,
+	client *kubernetes.Client, aiClient ai.IAI, analysisResults *[]Analysis) error {
+
+	list, err := client.GetClient().AppsV1().Deployments(config.Namespace).List(ctx, config.ListOptions())
+	if err != nil {
+		return err
+	}
+
+	threshold := d.UnavailableThreshold
+	if threshold == 0 {
+		threshold = DefaultUnavailableThreshold
+	}
+
+	var preAnalysis = map[string]PreAnalysis{}
+
+	for _, deployment := range list.Items {
+		if config.IsExcluded(deployment.Namespace) {
+			continue
+		}
+		var failures []Failure
+
+		var progressing, available *appsv1.DeploymentCondition
+		for i, condition := range deployment.Status.Conditions {
+			switch condition.Type {
+			case appsv1.DeploymentProgressing:
+				progressing = &deployment.Status.Conditions[i]
+			case appsv1.DeploymentAvailable:
+				available = &deployment.Status.Conditions[i]
+			case appsv1.DeploymentReplicaFailure:
+				if condition.Status == v1.ConditionTrue {
+					failures = append(failures, Failure{
+						Text:     fmt.Sprintf("Deployment failed to create replicas: %s", condition.Message),
+						Reason:   ReasonDeploymentReplicaFailure,
+						Severity: SeverityCritical,
+					})
+				}
+			}
+		}
+
+		// Check for rollouts that stopped making progress
+		if progressing != nil && progressing.Status == v1.ConditionFalse && progressing.Reason == "ProgressDeadlineExceeded" {
+			failures = append(failures, Failure{
+				Text:     fmt.Sprintf("Deployment rollout is stuck: %s", progressing.Message),
+				Reason:   ReasonDeploymentProgressDeadlineExceeded,
+				Severity: SeverityCritical,
+			})
+		}
+
+		// Check for deployments running below the desired replicas for too long
+		desired := int32(1)
+		if deployment.Spec.Replicas != nil {
+			desired = *deployment.Spec.Replicas
+		}
+		if deployment.Status.AvailableReplicas < desired {
+			// the deployment is unavailable since the Available condition turned false,
+			// otherwise since the rollout last made progress
+			var since time.Time
+			if available != nil && available.Status == v1.ConditionFalse {
+				since = available.LastTransitionTime.Time
+			} else if progressing != nil {
+				since = progressing.LastUpdateTime.Time
+			}
+			if !since.IsZero() && config.now().Sub(since) > threshold {
+				// the text stays the same as the available replicas change, so
+				// that the fingerprint of the failure does not change
+				failures = append(failures, Failure{
+					Text:     fmt.Sprintf("Deployment has fewer available replicas than desired for more than %s", threshold),
+					Reason:   ReasonDeploymentUnavailableReplicas,
+					Severity: SeverityWarning,
+					Metadata: map[string]string{
+						"availableReplicas": fmt.Sprint(deployment.Status.AvailableReplicas),
+						"desiredReplicas":   fmt.Sprint(desired),
+					},
+				})
+			}
+		}
+
+		if len(failures) > 0 {
+			preAnalysis[fmt.Sprintf("%s/%s", deployment.Namespace, deployment.Name)] = PreAnalysis{
+				Deployment:     deployment,
+				FailureDetails: failures,
+			}
+		}
+	}
+
+	for key, value := range preAnalysis {
+		var currentAnalysis = Analysis{
+			Kind:  "Deployment",
+			Name:  key,
+			Error: value.FailureDetails,
+		}
+
+		parent, _ := util.GetParent(client, value.Deployment.ObjectMeta)
+		currentAnalysis.ParentObject = parent
+		*analysisResults = append(*analysisResults, currentAnalysis)
+	}
+
+	return nil
+}
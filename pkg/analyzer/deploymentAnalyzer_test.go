@@ -0,0 +1,198 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/magiconair/properties/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDeploymentAnalyzer(t *testing.T) {
+
+	replicas := int32(3)
+	longAgo := metav1.NewTime(time.Now().Add(-time.Hour))
+	recently := metav1.NewTime(time.Now().Add(-time.Minute))
+
+	tests := []struct {
+		name    string
+		status  appsv1.DeploymentStatus
+		reasons []string
+	}{
+		{
+			name: "healthy",
+			status: appsv1.DeploymentStatus{
+				AvailableReplicas: 3,
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentAvailable, Status: v1.ConditionTrue},
+					{Type: appsv1.DeploymentProgressing, Status: v1.ConditionTrue, LastUpdateTime: longAgo},
+				},
+			},
+		},
+		{
+			name: "progress deadline exceeded",
+			status: appsv1.DeploymentStatus{
+				AvailableReplicas: 3,
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentProgressing, Status: v1.ConditionFalse, Reason: "ProgressDeadlineExceeded",
+						Message: `ReplicaSet "example-5d4f" has timed out progressing.`},
+				},
+			},
+			reasons: []string{ReasonDeploymentProgressDeadlineExceeded},
+		},
+		{
+			name: "unavailable for longer than the threshold",
+			status: appsv1.DeploymentStatus{
+				AvailableReplicas: 1,
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentAvailable, Status: v1.ConditionFalse, LastTransitionTime: longAgo},
+				},
+			},
+			reasons: []string{ReasonDeploymentUnavailableReplicas},
+		},
+		{
+			name: "unavailable for less than the threshold",
+			status: appsv1.DeploymentStatus{
+				AvailableReplicas: 1,
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentAvailable, Status: v1.ConditionFalse, LastTransitionTime: recently},
+				},
+			},
+		},
+		{
+			name: "replica failure",
+			status: appsv1.DeploymentStatus{
+				AvailableReplicas: 3,
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentReplicaFailure, Status: v1.ConditionTrue, Reason: "FailedCreate",
+						Message: `pods "example-5d4f-" is forbidden: exceeded quota`},
+				},
+			},
+			reasons: []string{ReasonDeploymentReplicaFailure},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "example",
+					Namespace: "default",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: &replicas,
+				},
+				Status: tt.status,
+			})
+
+			deploymentAnalyzer := DeploymentAnalyzer{UnavailableThreshold: 10 * time.Minute}
+			var analysisResults []Analysis
+			err := deploymentAnalyzer.RunAnalysis(context.Background(),
+				&AnalysisConfiguration{
+					Namespace: "default",
+				},
+				&kubernetes.Client{
+					Client: clientset,
+				}, nil, &analysisResults)
+			assert.Equal(t, err, nil)
+
+			var reasons []string
+			for _, analysis := range analysisResults {
+				for _, failure := range analysis.Error {
+					reasons = append(reasons, failure.Reason)
+				}
+			}
+			assert.Equal(t, reasons, tt.reasons)
+		})
+	}
+}
+
+func TestDeploymentAnalyzerUnavailableFingerprint(t *testing.T) {
+
+	// the fingerprint of an unavailable deployment does not change as its
+	// available replicas do
+	replicas := int32(3)
+	longAgo := metav1.NewTime(time.Now().Add(-time.Hour))
+	var ids []string
+	for _, available := range []int32{1, 2} {
+		clientset := fake.NewSimpleClientset(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "example",
+				Namespace: "default",
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+			},
+			Status: appsv1.DeploymentStatus{
+				AvailableReplicas: available,
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentAvailable, Status: v1.ConditionFalse, LastTransitionTime: longAgo},
+				},
+			},
+		})
+
+		var analysisResults []Analysis
+		err := RunAnalysis(context.Background(), []string{"Deployment"},
+			&AnalysisConfiguration{
+				Namespace: "default",
+			},
+			&kubernetes.Client{
+				Client: clientset,
+			}, nil, &analysisResults)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, len(analysisResults), 1)
+		assert.Equal(t, analysisResults[0].Error[0].Metadata["availableReplicas"], fmt.Sprint(available))
+		ids = append(ids, analysisResults[0].ID)
+	}
+	assert.Equal(t, ids[0], ids[1])
+}
+
+func TestDeploymentAnalyzerNow(t *testing.T) {
+
+	// a deployment unavailable for an hour was not unavailable for long when
+	// a snapshot was taken a minute after it became unavailable
+	replicas := int32(3)
+	unavailableSince := time.Now().Add(-time.Hour)
+	clientset := fake.NewSimpleClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example",
+			Namespace: "default",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+		Status: appsv1.DeploymentStatus{
+			AvailableReplicas: 1,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: v1.ConditionFalse,
+					LastTransitionTime: metav1.NewTime(unavailableSince)},
+			},
+		},
+	})
+
+	for _, tt := range []struct {
+		now      time.Time
+		problems int
+	}{
+		{now: time.Time{}, problems: 1},
+		{now: unavailableSince.Add(time.Minute), problems: 0},
+	} {
+		var analysisResults []Analysis
+		err := DeploymentAnalyzer{}.RunAnalysis(context.Background(),
+			&AnalysisConfiguration{
+				Namespace: "default",
+				Now:       tt.now,
+			},
+			&kubernetes.Client{
+				Client: clientset,
+			}, nil, &analysisResults)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, len(analysisResults), tt.problems)
+	}
+}
@@ -0,0 +1,71 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
+)
+
+// ExplainAnalysis sets the Details of each analysis to the explanation of
+// the AI backend, sending up to config.MaxConcurrency requests at once.
+// progress, if not nil, is called after each analysis. Failed analyses are
+// left without details, but when the backend keeps rate limiting requests
+// the remaining ones are cancelled.
+func ExplainAnalysis(ctx context.Context, config *AnalysisConfiguration, aiClient ai.IAI,
+	analysisResults []Analysis, progress func()) error {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	maxConcurrency := config.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = DefaultMaxConcurrency
+	}
+	semaphore := make(chan struct{}, maxConcurrency)
+
+	var (
+		wg          sync.WaitGroup
+		mutex       sync.Mutex
+		errs        []error
+		rateLimited error
+	)
+	for i := range analysisResults {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(analysis *Analysis) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			parsedText, err := ParseViaAI(ctx, config, aiClient, *analysis)
+			if progress != nil {
+				progress()
+			}
+			if err == nil {
+				analysis.Details = parsedText
+				return
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if ai.IsRateLimited(err) {
+				if rateLimited == nil {
+					rateLimited = err
+				}
+				cancel()
+				return
+			}
+			if !errors.Is(err, context.Canceled) {
+				errs = append(errs, fmt.Errorf("%s %s: %w", analysis.Kind, analysis.Name, err))
+			}
+		}(&analysisResults[i])
+	}
+	wg.Wait()
+
+	if rateLimited != nil {
+		return rateLimited
+	}
+	return errors.Join(errs...)
+}
@@ -15,7 +15,7 @@ type HpaAnalyzer struct{}
 func (HpaAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguration, client *kubernetes.Client, aiClient ai.IAI,
 	analysisResults *[]Analysis) error {
 
-	list, err := client.GetClient().AutoscalingV1().HorizontalPodAutoscalers(config.Namespace).List(ctx, metav1.ListOptions{})
+	list, err := client.GetClient().AutoscalingV1().HorizontalPodAutoscalers(config.Namespace).List(ctx, config.ListOptions())
 	if err != nil {
 		return err
 	}
@@ -23,7 +23,10 @@ func (HpaAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguratio
 	var preAnalysis = map[string]PreAnalysis{}
 
 	for _, hpa := range list.Items {
-		var failures []string
+		if config.IsExcluded(hpa.Namespace) {
+			continue
+		}
+		var failures []Failure
 
 		// check ScaleTargetRef exist
 		scaleTargetRef := hpa.Spec.ScaleTargetRef
@@ -51,11 +54,23 @@ func (HpaAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguratio
 				scaleTargetRefNotFound = true
 			}
 		default:
-			failures = append(failures, fmt.Sprintf("HorizontalPodAutoscaler uses %s as ScaleTargetRef which does not possible option.", scaleTargetRef.Kind))
+			failures = append(failures, Failure{
+				Text:     fmt.Sprintf("HorizontalPodAutoscaler uses %s as ScaleTargetRef which does not possible option.", scaleTargetRef.Kind),
+				Reason:   ReasonHPAScaleTargetInvalid,
+				Severity: SeverityWarning,
+				Metadata: map[string]string{
+					"scaleTargetKind": scaleTargetRef.Kind,
+				},
+			})
 		}
 
 		if scaleTargetRefNotFound {
-			failures = append(failures, fmt.Sprintf("HorizontalPodAutoscaler uses %s/%s as ScaleTargetRef which does not exist.", scaleTargetRef.Kind, scaleTargetRef.Name))
+			failures = append(failures, Failure{
+				Text:     fmt.Sprintf("HorizontalPodAutoscaler uses %s/%s as ScaleTargetRef which does not exist.", scaleTargetRef.Kind, scaleTargetRef.Name),
+				Reason:   ReasonHPAScaleTargetMissing,
+				Severity: SeverityWarning,
+				Objects:  []ObjectReference{{Kind: scaleTargetRef.Kind, Namespace: hpa.Namespace, Name: scaleTargetRef.Name}},
+			})
 		}
 
 		if len(failures) > 0 {
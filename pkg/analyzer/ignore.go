@@ -0,0 +1,157 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// ignoreRulesKey is the configuration key of the ignore rules
+const ignoreRulesKey = "ignore_rules"
+
+// expiresLayout is the layout of the expiry dates of ignore rules
+const expiresLayout = "2006-01-02"
+
+// IgnoreRule suppresses the known and accepted failures it matches. Empty
+// fields match anything, Name is a glob matched against the object name
+// without its namespace, and Kind is matched regardless of case. A rule with
+// an Expires date stops applying after that day.
+type IgnoreRule struct {
+	Kind          string `json:"kind,omitempty" yaml:"kind,omitempty"`
+	Namespace     string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Name          string `json:"name,omitempty" yaml:"name,omitempty"`
+	Reason        string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Expires       string `json:"expires,omitempty" yaml:"expires,omitempty"`
+	Justification string `json:"justification,omitempty" yaml:"justification,omitempty"`
+}
+
+// Validate checks that the rule matches something less than all failures,
+// and the syntax of its name glob and expiry date
+func (r IgnoreRule) Validate() error {
+	if r.Kind == "" && r.Namespace == "" && r.Name == "" && r.Reason == "" {
+		return errors.New("an ignore rule needs at least a kind, namespace, name or reason")
+	}
+	if _, err := path.Match(r.Name, ""); err != nil {
+		return fmt.Errorf("invalid name pattern %q: %w", r.Name, err)
+	}
+	if r.Expires != "" {
+		if _, err := time.Parse(expiresLayout, r.Expires); err != nil {
+			return fmt.Errorf("invalid expiry date %q, expected YYYY-MM-DD", r.Expires)
+		}
+	}
+	return nil
+}
+
+// Expired reports whether the expiry date of the rule is before now
+func (r IgnoreRule) Expired(now time.Time) bool {
+	if r.Expires == "" {
+		return false
+	}
+	expires, err := time.ParseInLocation(expiresLayout, r.Expires, now.Location())
+	if err != nil {
+		return false
+	}
+	// the rule applies until the end of its expiry day
+	return !now.Before(expires.AddDate(0, 0, 1))
+}
+
+// Matches reports whether the rule suppresses a failure of an analysis
+func (r IgnoreRule) Matches(analysis Analysis, failure Failure) bool {
+	namespace, name := "", analysis.Name
+	if i := strings.Index(analysis.Name, "/"); i >= 0 {
+		namespace, name = analysis.Name[:i], analysis.Name[i+1:]
+	}
+
+	if r.Kind != "" && !strings.EqualFold(r.Kind, analysis.Kind) {
+		return false
+	}
+	if r.Namespace != "" && r.Namespace != namespace {
+		return false
+	}
+	if r.Name != "" {
+		if ok, err := path.Match(r.Name, name); err != nil || !ok {
+			return false
+		}
+	}
+	return r.Reason == "" || r.Reason == failure.Reason
+}
+
+func (r IgnoreRule) String() string {
+	var fields []string
+	for _, field := range []struct{ name, value string }{
+		{"kind", r.Kind},
+		{"namespace", r.Namespace},
+		{"name", r.Name},
+		{"reason", r.Reason},
+		{"expires", r.Expires},
+	} {
+		if field.value != "" {
+			fields = append(fields, fmt.Sprintf("%s=%s", field.name, field.value))
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// ApplyIgnoreRules removes the failures matched by rules that have not
+// expired, and the results left without any failure. It returns the
+// remaining results and the number of results suppressed.
+func ApplyIgnoreRules(analysisResults []Analysis, rules []IgnoreRule, now time.Time) ([]Analysis, int) {
+	var active []IgnoreRule
+	for _, rule := range rules {
+		if !rule.Expired(now) {
+			active = append(active, rule)
+		}
+	}
+	if len(active) == 0 {
+		return analysisResults, 0
+	}
+
+	var (
+		filtered   []Analysis
+		suppressed int
+	)
+	for _, analysis := range analysisResults {
+		var failures []Failure
+		for _, failure := range analysis.Error {
+			if !matchesAny(active, analysis, failure) {
+				failures = append(failures, failure)
+			}
+		}
+		if len(failures) == 0 {
+			suppressed++
+			continue
+		}
+		analysis.Error = failures
+		analysis.Severity = analysis.HighestSeverity()
+		filtered = append(filtered, analysis)
+	}
+	return filtered, suppressed
+}
+
+func matchesAny(rules []IgnoreRule, analysis Analysis, failure Failure) bool {
+	for _, rule := range rules {
+		if rule.Matches(analysis, failure) {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadIgnoreRules returns the ignore rules of the configuration file
+func LoadIgnoreRules() ([]IgnoreRule, error) {
+	var rules []IgnoreRule
+	if err := viper.UnmarshalKey(ignoreRulesKey, &rules); err != nil {
+		return nil, fmt.Errorf("error reading ignore rules: %w", err)
+	}
+	return rules, nil
+}
+
+// SaveIgnoreRules replaces the ignore rules of the configuration file
+func SaveIgnoreRules(rules []IgnoreRule) error {
+	viper.Set(ignoreRulesKey, rules)
+	return viper.WriteConfig()
+}
@@ -15,7 +15,7 @@ type IngressAnalyzer struct{}
 func (IngressAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguration, client *kubernetes.Client, aiClient ai.IAI,
 	analysisResults *[]Analysis) error {
 
-	list, err := client.GetClient().NetworkingV1().Ingresses(config.Namespace).List(ctx, metav1.ListOptions{})
+	list, err := client.GetClient().NetworkingV1().Ingresses(config.Namespace).List(ctx, config.ListOptions())
 	if err != nil {
 		return err
 	}
@@ -23,14 +23,21 @@ func (IngressAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfigur
 	var preAnalysis = map[string]PreAnalysis{}
 
 	for _, ing := range list.Items {
-		var failures []string
+		if config.IsExcluded(ing.Namespace) {
+			continue
+		}
+		var failures []Failure
 
 		// get ingressClassName
 		ingressClassName := ing.Spec.IngressClassName
 		if ingressClassName == nil {
 			ingClassValue := ing.Annotations["kubernetes.io/ingress.class"]
 			if ingClassValue == "" {
-				failures = append(failures, fmt.Sprintf("Ingress %s/%s does not specify an Ingress class.", ing.Namespace, ing.Name))
+				failures = append(failures, Failure{
+					Text:     fmt.Sprintf("Ingress %s/%s does not specify an Ingress class.", ing.Namespace, ing.Name),
+					Reason:   ReasonIngressClassNotSpecified,
+					Severity: SeverityWarning,
+				})
 			} else {
 				ingressClassName = &ingClassValue
 			}
@@ -40,7 +47,12 @@ func (IngressAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfigur
 		if ingressClassName != nil {
 			_, err := client.GetClient().NetworkingV1().IngressClasses().Get(ctx, *ingressClassName, metav1.GetOptions{})
 			if err != nil {
-				failures = append(failures, fmt.Sprintf("Ingress uses the ingress class %s which does not exist.", *ingressClassName))
+				failures = append(failures, Failure{
+					Text:     fmt.Sprintf("Ingress uses the ingress class %s which does not exist.", *ingressClassName),
+					Reason:   ReasonIngressClassNotFound,
+					Severity: SeverityCritical,
+					Objects:  []ObjectReference{{Kind: "IngressClass", Name: *ingressClassName}},
+				})
 			}
 		}
 
@@ -50,7 +62,12 @@ func (IngressAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfigur
 			for _, path := range rule.HTTP.Paths {
 				_, err := client.GetClient().CoreV1().Services(ing.Namespace).Get(ctx, path.Backend.Service.Name, metav1.GetOptions{})
 				if err != nil {
-					failures = append(failures, fmt.Sprintf("Ingress uses the service %s/%s which does not exist.", ing.Namespace, path.Backend.Service.Name))
+					failures = append(failures, Failure{
+						Text:     fmt.Sprintf("Ingress uses the service %s/%s which does not exist.", ing.Namespace, path.Backend.Service.Name),
+						Reason:   ReasonIngressBackendServiceMissing,
+						Severity: SeverityCritical,
+						Objects:  []ObjectReference{{Kind: "Service", Namespace: ing.Namespace, Name: path.Backend.Service.Name}},
+					})
 				}
 			}
 		}
@@ -58,7 +75,12 @@ func (IngressAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfigur
 		for _, tls := range ing.Spec.TLS {
 			_, err := client.GetClient().CoreV1().Secrets(ing.Namespace).Get(ctx, tls.SecretName, metav1.GetOptions{})
 			if err != nil {
-				failures = append(failures, fmt.Sprintf("Ingress uses the secret %s/%s as a TLS certificate which does not exist.", ing.Namespace, tls.SecretName))
+				failures = append(failures, Failure{
+					Text:     fmt.Sprintf("Ingress uses the secret %s/%s as a TLS certificate which does not exist.", ing.Namespace, tls.SecretName),
+					Reason:   ReasonIngressTLSSecretMissing,
+					Severity: SeverityCritical,
+					Objects:  []ObjectReference{{Kind: "Secret", Namespace: ing.Namespace, Name: tls.SecretName}},
+				})
 			}
 		}
 		if len(failures) > 0 {
@@ -0,0 +1,138 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/util"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var jobFailureReasons = map[string]string{
+	"BackoffLimitExceeded": ReasonJobBackoffLimitExceeded,
+	"DeadlineExceeded":     ReasonJobDeadlineExceeded,
+}
+
+type JobAnalyzer struct{}
+
+func (JobAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguration,
+	client *kubernetes.Client, aiClient ai.IAI, analysisResults *[]Analysis) error {
+
+	list, err := client.GetClient().BatchV1().Jobs(config.Namespace).List(ctx, config.ListOptions())
+	if err != nil {
+		return err
+	}
+
+	var preAnalysis = map[string]PreAnalysis{}
+
+	for _, job := range list.Items {
+		if config.IsExcluded(job.Namespace) {
+			continue
+		}
+		var failures []Failure
+
+		for _, condition := range job.Status.Conditions {
+			reason, ok := jobFailureReasons[condition.Reason]
+			if condition.Type != batchv1.JobFailed || condition.Status != v1.ConditionTrue || !ok {
+				continue
+			}
+			failure := Failure{
+				Text:     fmt.Sprintf("Job failed: %s", condition.Message),
+				Reason:   reason,
+				Severity: SeverityCritical,
+			}
+			// add the termination message of the last failing pod
+			pod, status, err := findFailedJobPod(ctx, client, job)
+			if err != nil {
+				return err
+			}
+			if status != nil {
+				failure.Text = fmt.Sprintf("%s, container %s of pod %s terminated with exit code %d: %s",
+					failure.Text, status.Name, pod.Name, terminatedState(*status).ExitCode, terminationMessage(*status))
+				failure.Objects = []ObjectReference{{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name}}
+				failure.Metadata = map[string]string{
+					"container": status.Name,
+				}
+			}
+			failures = append(failures, failure)
+		}
+
+		if len(failures) > 0 {
+			preAnalysis[fmt.Sprintf("%s/%s", job.Namespace, job.Name)] = PreAnalysis{
+				Job:            job,
+				FailureDetails: failures,
+			}
+		}
+	}
+
+	for key, value := range preAnalysis {
+		var currentAnalysis = Analysis{
+			Kind:  "Job",
+			Name:  key,
+			Error: value.FailureDetails,
+		}
+
+		parent, _ := util.GetParent(client, value.Job.ObjectMeta)
+		currentAnalysis.ParentObject = parent
+		*analysisResults = append(*analysisResults, currentAnalysis)
+	}
+
+	return nil
+}
+
+// findFailedJobPod returns the most recently terminated container of the
+// pods of a job that exited with an error
+func findFailedJobPod(ctx context.Context, client *kubernetes.Client, job batchv1.Job) (*v1.Pod, *v1.ContainerStatus, error) {
+	selector := "job-name=" + job.Name
+	if job.Spec.Selector != nil {
+		s, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+		if err != nil {
+			return nil, nil, err
+		}
+		selector = s.String()
+	}
+	pods, err := client.GetClient().CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		lastPod    *v1.Pod
+		lastStatus *v1.ContainerStatus
+	)
+	for i, pod := range pods.Items {
+		for j, status := range pod.Status.ContainerStatuses {
+			terminated := terminatedState(status)
+			if terminated == nil || terminated.ExitCode == 0 {
+				continue
+			}
+			if lastStatus == nil || terminated.FinishedAt.After(terminatedState(*lastStatus).FinishedAt.Time) {
+				lastPod = &pods.Items[i]
+				lastStatus = &pods.Items[i].Status.ContainerStatuses[j]
+			}
+		}
+	}
+	return lastPod, lastStatus, nil
+}
+
+// terminatedState returns the current or last termination of a container
+func terminatedState(status v1.ContainerStatus) *v1.ContainerStateTerminated {
+	if status.State.Terminated != nil {
+		return status.State.Terminated
+	}
+	return status.LastTerminationState.Terminated
+}
+
+func terminationMessage(status v1.ContainerStatus) string {
+	terminated := terminatedState(status)
+	if terminated.Message != "" {
+		return terminated.Message
+	}
+	return terminated.Reason
+}
@@ -0,0 +1,77 @@
+package analyzer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/magiconair/properties/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestJobAnalyzer(t *testing.T) {
+
+	clientset := fake.NewSimpleClientset(
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "failed", Namespace: "default"},
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{
+					{
+						Type:    batchv1.JobFailed,
+						Status:  v1.ConditionTrue,
+						Reason:  "BackoffLimitExceeded",
+						Message: "Job has reached the specified backoff limit",
+					},
+				},
+			},
+		},
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "complete", Namespace: "default"},
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{
+					{Type: batchv1.JobComplete, Status: v1.ConditionTrue},
+				},
+			},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "failed-abcde",
+				Namespace: "default",
+				Labels:    map[string]string{"job-name": "failed"},
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						Name: "migrate",
+						State: v1.ContainerState{
+							Terminated: &v1.ContainerStateTerminated{
+								ExitCode: 1,
+								Message:  "connection refused",
+							},
+						},
+					},
+				},
+			},
+		})
+
+	jobAnalyzer := JobAnalyzer{}
+	var analysisResults []Analysis
+	err := jobAnalyzer.RunAnalysis(context.Background(),
+		&AnalysisConfiguration{
+			Namespace: "default",
+		},
+		&kubernetes.Client{
+			Client: clientset,
+		}, nil, &analysisResults)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(analysisResults), 1)
+
+	failure := analysisResults[0].Error[0]
+	assert.Equal(t, failure.Reason, ReasonJobBackoffLimitExceeded)
+	assert.Equal(t, failure.Metadata["container"], "migrate")
+	assert.Equal(t, strings.HasSuffix(failure.Text, "exit code 1: connection refused"), true)
+}
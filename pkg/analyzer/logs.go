@@ -0,0 +1,72 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultLogLines is the number of lines fetched from each container log
+	DefaultLogLines = 50
+	// maxLogBytes caps the size of a log excerpt, only its end is kept
+	maxLogBytes = 4096
+)
+
+// ContainerLog is the end of the log of a container. Previous is set for
+// the log of the container instance that ran before the last restart.
+type ContainerLog struct {
+	Container string `json:"container"`
+	Previous  bool   `json:"previous,omitempty"`
+	Text      string `json:"text"`
+}
+
+func (l ContainerLog) String() string {
+	if l.Previous {
+		return fmt.Sprintf("container %s (previous):\n%s", l.Container, l.Text)
+	}
+	return fmt.Sprintf("container %s:\n%s", l.Container, l.Text)
+}
+
+// FetchContainerLogs returns the last lines of the previous and current logs
+// of a container. Logs that cannot be fetched, e.g. because the container
+// never restarted, are skipped.
+func FetchContainerLogs(ctx context.Context, kubernetesClient *kubernetes.Client, pod v1.Pod, container string, lines int64) []ContainerLog {
+	var logs []ContainerLog
+	for _, previous := range []bool{true, false} {
+		raw, err := kubernetesClient.GetClient().CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{
+			Container: container,
+			Previous:  previous,
+			TailLines: &lines,
+		}).DoRaw(ctx)
+		if err != nil {
+			continue
+		}
+		text := truncateLog(strings.TrimSpace(string(raw)))
+		if text == "" {
+			continue
+		}
+		logs = append(logs, ContainerLog{
+			Container: container,
+			Previous:  previous,
+			Text:      text,
+		})
+	}
+	return logs
+}
+
+// truncateLog keeps the end of a log within maxLogBytes, starting at a line
+// boundary
+func truncateLog(text string) string {
+	if len(text) <= maxLogBytes {
+		return text
+	}
+	text = text[len(text)-maxLogBytes:]
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		text = text[i+1:]
+	}
+	return text
+}
@@ -0,0 +1,123 @@
+package analyzer
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	analyzerDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "k8sgpt_analyzer_duration_seconds",
+		Help:    "Duration of analyzer runs.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"analyzer"})
+	analyzerErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8sgpt_analyzer_errors_total",
+		Help: "Number of analyzer runs that failed.",
+	}, []string{"analyzer"})
+	aiRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8sgpt_ai_requests_total",
+		Help: "Number of requests sent to the AI backend.",
+	}, []string{"backend", "model"})
+	aiRequestErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8sgpt_ai_request_errors_total",
+		Help: "Number of requests to the AI backend that failed.",
+	}, []string{"backend", "model"})
+	aiRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "k8sgpt_ai_request_duration_seconds",
+		Help:    "Duration of requests to the AI backend.",
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"backend", "model"})
+	cacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8sgpt_cache_lookups_total",
+		Help: "Number of lookups of AI responses in the cache by result, hit or miss.",
+	}, []string{"result"})
+)
+
+// cacheHits and cacheMisses count the cache lookups for the hit ratio, the
+// counters of the lookups cannot be read back
+var cacheHits, cacheMisses atomic.Uint64
+
+func init() {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "k8sgpt_cache_hit_ratio",
+		Help: "Ratio of lookups of AI responses found in the cache.",
+	}, func() float64 {
+		hits, misses := float64(cacheHits.Load()), float64(cacheMisses.Load())
+		if hits+misses == 0 {
+			return 0
+		}
+		return hits / (hits + misses)
+	})
+}
+
+// recordCacheLookup counts a lookup of an AI response in the cache
+func recordCacheLookup(hit bool) {
+	if hit {
+		cacheHits.Add(1)
+		cacheLookups.WithLabelValues("hit").Inc()
+		return
+	}
+	cacheMisses.Add(1)
+	cacheLookups.WithLabelValues("miss").Inc()
+}
+
+// findingsDesc describes the findings metric, whose series are replaced as
+// a whole by each analysis
+var findingsDesc = prometheus.NewDesc("k8sgpt_findings",
+	"Number of failures found by the last analysis.",
+	[]string{"kind", "namespace", "reason", "severity"}, nil)
+
+// findingsKey holds the label values of a series of the findings metric
+type findingsKey struct {
+	kind, namespace, reason, severity string
+}
+
+// findingsCollector reports the findings of the last analysis. They are
+// swapped in at once, so that a scrape never sees a partial analysis.
+type findingsCollector struct {
+	mutex    sync.Mutex
+	findings map[findingsKey]float64
+}
+
+var findings = &findingsCollector{}
+
+func init() {
+	prometheus.MustRegister(findings)
+}
+
+func (c *findingsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- findingsDesc
+}
+
+func (c *findingsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for key, value := range c.findings {
+		ch <- prometheus.MustNewConstMetric(findingsDesc, prometheus.GaugeValue, value,
+			key.kind, key.namespace, key.reason, key.severity)
+	}
+}
+
+// RecordFindings sets the findings metric to the failures of results,
+// removing the findings of earlier analyses
+func RecordFindings(analysisResults []Analysis) {
+	series := map[findingsKey]float64{}
+	for _, analysis := range analysisResults {
+		var namespace string
+		if i := strings.Index(analysis.Name, "/"); i >= 0 {
+			namespace = analysis.Name[:i]
+		}
+		for _, failure := range analysis.Error {
+			series[findingsKey{analysis.Kind, namespace, failure.Reason, failure.Severity.String()}]++
+		}
+	}
+
+	findings.mutex.Lock()
+	defer findings.mutex.Unlock()
+	findings.findings = series
+}
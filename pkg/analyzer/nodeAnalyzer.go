@@ -0,0 +1,197 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/util"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// DefaultCordonThreshold is how long a node can stay cordoned before it is
+// reported. The API server does not record when a node was cordoned, the
+// NodeNotSchedulable event of the kubelet tells it, but events are only kept
+// for an hour by default. Nodes cordoned without such an event left are
+// reported whatever the threshold, so it is kept below the event retention.
+const DefaultCordonThreshold = 30 * time.Minute
+
+var nodePressureReasons = map[v1.NodeConditionType]string{
+	v1.NodeMemoryPressure: ReasonNodeMemoryPressure,
+	v1.NodeDiskPressure:   ReasonNodeDiskPressure,
+	v1.NodePIDPressure:    ReasonNodePIDPressure,
+}
+
+type NodeAnalyzer struct {
+	// CordonThreshold overrides DefaultCordonThreshold when set
+	CordonThreshold time.Duration
+}
+
+func (n NodeAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguration,
+	client *kubernetes.Client, aiClient ai.IAI, analysisResults *[]Analysis) error {
+
+	list, err := client.GetClient().CoreV1().Nodes().List(ctx, config.ClusterListOptions())
+	if err != nil {
+		return err
+	}
+
+	threshold := n.CordonThreshold
+	if threshold == 0 {
+		threshold = DefaultCordonThreshold
+	}
+
+	var preAnalysis = map[string]PreAnalysis{}
+
+	for _, node := range list.Items {
+		var failures []Failure
+
+		for _, condition := range node.Status.Conditions {
+			switch condition.Type {
+			case v1.NodeReady:
+				if condition.Status != v1.ConditionTrue {
+					failures = append(failures, Failure{
+						Text:     fmt.Sprintf("Node is not ready: %s", condition.Message),
+						Reason:   ReasonNodeNotReady,
+						Severity: SeverityCritical,
+					})
+				}
+			case v1.NodeMemoryPressure, v1.NodeDiskPressure, v1.NodePIDPressure:
+				if condition.Status == v1.ConditionTrue {
+					failures = append(failures, Failure{
+						Text:     fmt.Sprintf("Node has %s: %s", condition.Type, condition.Message),
+						Reason:   nodePressureReasons[condition.Type],
+						Severity: SeverityWarning,
+					})
+				}
+			case v1.NodeNetworkUnavailable:
+				if condition.Status == v1.ConditionTrue {
+					failures = append(failures, Failure{
+						Text:     fmt.Sprintf("Node network is unavailable: %s", condition.Message),
+						Reason:   ReasonNodeNetworkUnavailable,
+						Severity: SeverityCritical,
+					})
+				}
+			}
+		}
+
+		// Check for nodes left cordoned, the kubelet event tells since when
+		// if it was not removed yet
+		if node.Spec.Unschedulable {
+			since, err := fetchCordonTime(ctx, client, node.Name)
+			if err != nil {
+				return err
+			}
+			if since.IsZero() || config.now().Sub(since) > threshold {
+				failure := Failure{
+					Text:     "Node is cordoned",
+					Reason:   ReasonNodeCordoned,
+					Severity: SeverityWarning,
+				}
+				if !since.IsZero() {
+					failure.Metadata = map[string]string{
+						"cordonedSince": since.Format(time.RFC3339),
+					}
+				}
+				failures = append(failures, failure)
+			}
+		}
+
+		if len(failures) > 0 {
+			affected, err := findAffectedPods(ctx, config, client, node.Name)
+			if err != nil {
+				return err
+			}
+			if affected != nil {
+				failures = append(failures, *affected)
+			}
+			preAnalysis[node.Name] = PreAnalysis{
+				Node:           node,
+				FailureDetails: failures,
+			}
+		}
+	}
+
+	for key, value := range preAnalysis {
+		var currentAnalysis = Analysis{
+			Kind:  "Node",
+			Name:  key,
+			Error: value.FailureDetails,
+		}
+
+		parent, _ := util.GetParent(client, value.Node.ObjectMeta)
+		currentAnalysis.ParentObject = parent
+		*analysisResults = append(*analysisResults, currentAnalysis)
+	}
+
+	return nil
+}
+
+// fetchCordonTime returns when a node was last cordoned according to the
+// NodeNotSchedulable events of the kubelet, or the zero time if there are
+// none left
+func fetchCordonTime(ctx context.Context, client *kubernetes.Client, nodeName string) (time.Time, error) {
+	events, err := client.GetClient().CoreV1().Events("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.SelectorFromSet(fields.Set{
+			"involvedObject.kind": "Node",
+			"involvedObject.name": nodeName,
+			"reason":              "NodeNotSchedulable",
+		}).String(),
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	var since time.Time
+	for _, event := range events.Items {
+		// clients may not honour the field selector
+		if event.InvolvedObject.Kind != "Node" || event.InvolvedObject.Name != nodeName ||
+			event.Reason != "NodeNotSchedulable" {
+			continue
+		}
+		timestamp := event.LastTimestamp.Time
+		if timestamp.IsZero() {
+			timestamp = event.EventTime.Time
+		}
+		if timestamp.After(since) {
+			since = timestamp
+		}
+	}
+	return since, nil
+}
+
+// findAffectedPods returns a failure referencing the analyzed pods scheduled
+// on a node, or nil if there are none. The pods are only referenced in the
+// objects of the failure, so that its text does not change as they churn.
+func findAffectedPods(ctx context.Context, config *AnalysisConfiguration, client *kubernetes.Client,
+	nodeName string) (*Failure, error) {
+
+	pods, err := client.GetClient().CoreV1().Pods(config.Namespace).List(ctx,
+		config.ListOptions(fields.OneTermEqualSelector("spec.nodeName", nodeName)))
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []ObjectReference
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != nodeName || config.IsExcluded(pod.Namespace) {
+			continue
+		}
+		objects = append(objects, ObjectReference{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name})
+	}
+	if len(objects) == 0 {
+		return nil, nil
+	}
+
+	return &Failure{
+		Text:     "Pods on the node may be affected",
+		Reason:   ReasonNodeAffectedPods,
+		Severity: SeverityInfo,
+		Objects:  objects,
+		Metadata: map[string]string{
+			"affectedPods": fmt.Sprint(len(objects)),
+		},
+	}, nil
+}
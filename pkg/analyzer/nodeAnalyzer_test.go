@@ -0,0 +1,130 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/magiconair/properties/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNodeAnalyzer(t *testing.T) {
+
+	cordoned := v1.NodeSpec{
+		Unschedulable: true,
+		Taints: []v1.Taint{
+			{Key: v1.TaintNodeUnschedulable, Effect: v1.TaintEffectNoSchedule},
+		},
+	}
+	// the event recorded by the kubelet when the node was cordoned
+	cordonEvent := func(ago time.Duration) *v1.Event {
+		return &v1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "node-1.cordoned", Namespace: "default"},
+			InvolvedObject: v1.ObjectReference{Kind: "Node", Name: "node-1", UID: "node-1"},
+			Reason:         "NodeNotSchedulable",
+			LastTimestamp:  metav1.NewTime(time.Now().Add(-ago)),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		spec       v1.NodeSpec
+		conditions []v1.NodeCondition
+		events     []runtime.Object
+		reasons    []string
+	}{
+		{
+			name: "healthy",
+			conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionTrue},
+				{Type: v1.NodeMemoryPressure, Status: v1.ConditionFalse},
+			},
+		},
+		{
+			name: "not ready",
+			conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionUnknown, Message: "Kubelet stopped posting node status."},
+			},
+			reasons: []string{ReasonNodeNotReady, ReasonNodeAffectedPods},
+		},
+		{
+			name: "under pressure",
+			conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionTrue},
+				{Type: v1.NodeDiskPressure, Status: v1.ConditionTrue},
+				{Type: v1.NodePIDPressure, Status: v1.ConditionTrue},
+				{Type: v1.NodeNetworkUnavailable, Status: v1.ConditionTrue},
+			},
+			reasons: []string{ReasonNodeDiskPressure, ReasonNodePIDPressure, ReasonNodeNetworkUnavailable, ReasonNodeAffectedPods},
+		},
+		{
+			name:    "cordoned for longer than the threshold",
+			spec:    cordoned,
+			events:  []runtime.Object{cordonEvent(time.Hour)},
+			reasons: []string{ReasonNodeCordoned, ReasonNodeAffectedPods},
+		},
+		{
+			name:   "cordoned for less than the threshold",
+			spec:   cordoned,
+			events: []runtime.Object{cordonEvent(time.Minute)},
+		},
+		{
+			name:    "cordoned for longer than the event retention",
+			spec:    cordoned,
+			reasons: []string{ReasonNodeCordoned, ReasonNodeAffectedPods},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(append(tt.events,
+				&v1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+					Spec:       tt.spec,
+					Status:     v1.NodeStatus{Conditions: tt.conditions},
+				},
+				&v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "on-node", Namespace: "default"},
+					Spec:       v1.PodSpec{NodeName: "node-1"},
+				},
+				&v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "excluded", Namespace: "kube-system"},
+					Spec:       v1.PodSpec{NodeName: "node-1"},
+				},
+				&v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "elsewhere", Namespace: "default"},
+					Spec:       v1.PodSpec{NodeName: "node-2"},
+				})...)
+
+			nodeAnalyzer := NodeAnalyzer{}
+			var analysisResults []Analysis
+			err := nodeAnalyzer.RunAnalysis(context.Background(),
+				&AnalysisConfiguration{
+					ExcludeNamespaces: []string{"kube-system"},
+				},
+				&kubernetes.Client{
+					Client: clientset,
+				}, nil, &analysisResults)
+			assert.Equal(t, err, nil)
+
+			var reasons []string
+			for _, analysis := range analysisResults {
+				for _, failure := range analysis.Error {
+					reasons = append(reasons, failure.Reason)
+					if failure.Reason == ReasonNodeAffectedPods {
+						// the pods are kept out of the text so that the fingerprint is stable
+						assert.Equal(t, failure.Text, "Pods on the node may be affected")
+						assert.Equal(t, failure.Metadata["affectedPods"], "1")
+						assert.Equal(t, failure.Objects, []ObjectReference{{Kind: "Pod", Namespace: "default", Name: "on-node"}})
+					}
+				}
+			}
+			assert.Equal(t, reasons, tt.reasons)
+		})
+	}
+}
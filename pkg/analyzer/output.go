@@ -0,0 +1,46 @@
+package analyzer
+
+type OutputStatus string
+
+const (
+	StatusOK              OutputStatus = "OK"
+	StatusProblemDetected OutputStatus = "ProblemDetected"
+)
+
+// Output is the JSON document describing the results of an analysis, as
+// printed by analyze --output json-v2 and returned by the server. Errors holds
+// the errors of the analyzers that failed, the results of the others are
+// still included, and Suppressed counts the results left out by ignore
+// rules.
+type Output struct {
+	Status     OutputStatus `json:"status"`
+	Problems   int          `json:"problems"`
+	Suppressed int          `json:"suppressed,omitempty"`
+	Errors     []string     `json:"errors,omitempty"`
+	Results    []Analysis   `json:"results"`
+}
+
+// NewOutput returns the output of the results of RunAnalysis and the error
+// it returned
+func NewOutput(analysisResults []Analysis, err error) Output {
+	output := Output{
+		Status:   StatusOK,
+		Problems: len(analysisResults),
+		Results:  analysisResults,
+	}
+	if output.Results == nil {
+		output.Results = []Analysis{}
+	}
+	if len(analysisResults) > 0 {
+		output.Status = StatusProblemDetected
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range joined.Unwrap() {
+			output.Errors = append(output.Errors, err.Error())
+		}
+	} else if err != nil {
+		output.Errors = []string{err.Error()}
+	}
+	return output
+}
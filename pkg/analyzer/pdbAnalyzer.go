@@ -7,7 +7,6 @@ import (
 	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
 	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
 	"github.com/k8sgpt-ai/k8sgpt/pkg/util"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type PdbAnalyzer struct{}
@@ -15,7 +14,7 @@ type PdbAnalyzer struct{}
 func (PdbAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguration, client *kubernetes.Client, aiClient ai.IAI,
 	analysisResults *[]Analysis) error {
 
-	list, err := client.GetClient().PolicyV1().PodDisruptionBudgets(config.Namespace).List(ctx, metav1.ListOptions{})
+	list, err := client.GetClient().PolicyV1().PodDisruptionBudgets(config.Namespace).List(ctx, config.ListOptions())
 	if err != nil {
 		return err
 	}
@@ -23,7 +22,10 @@ func (PdbAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguratio
 	var preAnalysis = map[string]PreAnalysis{}
 
 	for _, pdb := range list.Items {
-		var failures []string
+		if config.IsExcluded(pdb.Namespace) {
+			continue
+		}
+		var failures []Failure
 
 		evt, err := FetchLatestEvent(ctx, client, pdb.Namespace, pdb.Name)
 		if err != nil || evt == nil {
@@ -33,13 +35,25 @@ func (PdbAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguratio
 		if evt.Reason == "NoPods" && evt.Message != "" {
 			if pdb.Spec.Selector != nil {
 				for k, v := range pdb.Spec.Selector.MatchLabels {
-					failures = append(failures, fmt.Sprintf("%s, expected label %s=%s", evt.Message, k, v))
+					failures = append(failures, Failure{
+						Text:     fmt.Sprintf("%s, expected label %s=%s", evt.Message, k, v),
+						Reason:   ReasonPDBNoPods,
+						Severity: SeverityInfo,
+					})
 				}
 				for _, v := range pdb.Spec.Selector.MatchExpressions {
-					failures = append(failures, fmt.Sprintf("%s, expected expression %s", evt.Message, v))
+					failures = append(failures, Failure{
+						Text:     fmt.Sprintf("%s, expected expression %s", evt.Message, v),
+						Reason:   ReasonPDBNoPods,
+						Severity: SeverityInfo,
+					})
 				}
 			} else {
-				failures = append(failures, fmt.Sprintf("%s, selector is nil", evt.Message))
+				failures = append(failures, Failure{
+					Text:     fmt.Sprintf("%s, selector is nil", evt.Message),
+					Reason:   ReasonPDBNoPods,
+					Severity: SeverityInfo,
+				})
 			}
 		}
 
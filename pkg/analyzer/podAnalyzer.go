@@ -7,23 +7,60 @@ import (
 	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
 	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
 	"github.com/k8sgpt-ai/k8sgpt/pkg/util"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/api/core/v1"
 )
 
-type PodAnalyzer struct{}
+// DefaultRestartThreshold is the number of restarts after which a container
+// is reported even if it is currently running
+const DefaultRestartThreshold = 5
 
-func (PodAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguration,
+// podWaitingReasons maps the waiting reasons of a container that indicate
+// it cannot start to the reason code of the failure
+var podWaitingReasons = map[string]string{
+	"CrashLoopBackOff":           ReasonCrashLoopBackOff,
+	"ImagePullBackOff":           ReasonImagePullBackOff,
+	"ErrImagePull":               ReasonErrImagePull,
+	"InvalidImageName":           ReasonInvalidImageName,
+	"CreateContainerConfigError": ReasonCreateContainerConfigError,
+}
+
+// podLogReasons are the failures of a container whose logs explain them
+var podLogReasons = map[string]bool{
+	ReasonCrashLoopBackOff:             true,
+	ReasonContainerOOMKilled:           true,
+	ReasonContainerTerminatedWithError: true,
+	ReasonContainerRestarting:          true,
+}
+
+type PodAnalyzer struct {
+	// RestartThreshold overrides DefaultRestartThreshold when set
+	RestartThreshold int32
+}
+
+func (p PodAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguration,
 	client *kubernetes.Client, aiClient ai.IAI, analysisResults *[]Analysis) error {
 
+	restartThreshold := p.RestartThreshold
+	if restartThreshold == 0 {
+		restartThreshold = DefaultRestartThreshold
+	}
+	logLines := config.LogLines
+	if logLines == 0 {
+		logLines = DefaultLogLines
+	}
+
 	// search all namespaces for pods that are not running
-	list, err := client.GetClient().CoreV1().Pods(config.Namespace).List(ctx, metav1.ListOptions{})
+	list, err := client.GetClient().CoreV1().Pods(config.Namespace).List(ctx, config.ListOptions())
 	if err != nil {
 		return err
 	}
 	var preAnalysis = map[string]PreAnalysis{}
 
 	for _, pod := range list.Items {
-		var failures []string
+		if config.IsExcluded(pod.Namespace) {
+			continue
+		}
+		var failures []Failure
 		// Check for pending pods
 		if pod.Status.Phase == "Pending" {
 
@@ -31,38 +68,48 @@ func (PodAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguratio
 			for _, containerStatus := range pod.Status.Conditions {
 				if containerStatus.Type == "PodScheduled" && containerStatus.Reason == "Unschedulable" {
 					if containerStatus.Message != "" {
-						failures = []string{containerStatus.Message}
+						failures = []Failure{{
+							Text:     containerStatus.Message,
+							Reason:   ReasonPodUnschedulable,
+							Severity: SeverityCritical,
+						}}
 					}
 				}
 			}
 		}
 
+		failures = append(failures, analyzeContainerStatuses(pod, "init container", pod.Status.InitContainerStatuses, restartThreshold)...)
+		failures = append(failures, analyzeContainerStatuses(pod, "container", pod.Status.ContainerStatuses, restartThreshold)...)
+		failures = append(failures, analyzeContainerStatuses(pod, "ephemeral container", pod.Status.EphemeralContainerStatuses, restartThreshold)...)
+
 		// Check through container status to check for crashes
 		for _, containerStatus := range pod.Status.ContainerStatuses {
-			if containerStatus.State.Waiting != nil {
-				if containerStatus.State.Waiting.Reason == "CrashLoopBackOff" || containerStatus.State.Waiting.Reason == "ImagePullBackOff" {
-					if containerStatus.State.Waiting.Message != "" {
-						failures = append(failures, containerStatus.State.Waiting.Message)
-					}
-				}
-				// This represents a container that is still being created or blocked due to conditions such as OOMKilled
-				if containerStatus.State.Waiting.Reason == "ContainerCreating" && pod.Status.Phase == "Pending" {
+			// This represents a container that is still being created or blocked due to conditions such as OOMKilled
+			if containerStatus.State.Waiting != nil && containerStatus.State.Waiting.Reason == "ContainerCreating" && pod.Status.Phase == "Pending" {
 
-					// parse the event log and append details
-					evt, err := FetchLatestEvent(ctx, client, pod.Namespace, pod.Name)
-					if err != nil || evt == nil {
-						continue
-					}
-					if evt.Reason == "FailedCreatePodSandBox" && evt.Message != "" {
-						failures = append(failures, evt.Message)
-					}
+				// parse the event log and append details
+				evt, err := FetchLatestEvent(ctx, client, pod.Namespace, pod.Name)
+				if err != nil || evt == nil {
+					continue
+				}
+				if evt.Reason == "FailedCreatePodSandBox" && evt.Message != "" {
+					failures = append(failures, Failure{
+						Text:     evt.Message,
+						Reason:   ReasonPodSandboxCreateFailed,
+						Severity: SeverityCritical,
+					})
 				}
 			}
 		}
 		if len(failures) > 0 {
+			var logs []ContainerLog
+			if config.WithLogs {
+				logs = fetchFailureLogs(ctx, client, pod, failures, logLines)
+			}
 			preAnalysis[fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)] = PreAnalysis{
 				Pod:            pod,
 				FailureDetails: failures,
+				Logs:           logs,
 			}
 		}
 	}
@@ -72,6 +119,7 @@ func (PodAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguratio
 			Kind:  "Pod",
 			Name:  key,
 			Error: value.FailureDetails,
+			Logs:  value.Logs,
 		}
 
 		parent, _ := util.GetParent(client, value.Pod.ObjectMeta)
@@ -81,3 +129,109 @@ func (PodAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguratio
 
 	return nil
 }
+
+// analyzeContainerStatuses returns the failures of one kind of containers of
+// a pod, described as containerType in the failure text
+func analyzeContainerStatuses(pod v1.Pod, containerType string, statuses []v1.ContainerStatus, restartThreshold int32) []Failure {
+	var failures []Failure
+	for _, status := range statuses {
+		metadata := map[string]string{
+			"container": status.Name,
+		}
+		var containerFailures []Failure
+
+		if waiting := status.State.Waiting; waiting != nil {
+			if reason, ok := podWaitingReasons[waiting.Reason]; ok {
+				message := waiting.Message
+				if message == "" {
+					message = waiting.Reason
+				}
+				containerFailures = append(containerFailures, Failure{
+					Text:     fmt.Sprintf("%s %s: %s", containerType, status.Name, message),
+					Reason:   reason,
+					Severity: SeverityCritical,
+					Metadata: metadata,
+				})
+			}
+		}
+
+		// a termination is only relevant while the container is not running
+		// again, a recovered container is caught by the restart count below
+		if terminated := terminatedState(status); terminated != nil && status.State.Running == nil {
+			if terminated.Reason == "OOMKilled" {
+				text := fmt.Sprintf("%s %s was OOMKilled", containerType, status.Name)
+				if limit := memoryLimit(pod, status.Name); limit != "" {
+					text = fmt.Sprintf("%s, its memory limit is %s", text, limit)
+				}
+				containerFailures = append(containerFailures, Failure{
+					Text:     text,
+					Reason:   ReasonContainerOOMKilled,
+					Severity: SeverityCritical,
+					Metadata: metadata,
+				})
+			} else if terminated.ExitCode != 0 {
+				containerFailures = append(containerFailures, Failure{
+					Text: fmt.Sprintf("%s %s terminated with exit code %d: %s",
+						containerType, status.Name, terminated.ExitCode, terminationMessage(status)),
+					Reason:   ReasonContainerTerminatedWithError,
+					Severity: SeverityCritical,
+					Metadata: metadata,
+				})
+			}
+		}
+
+		if len(containerFailures) == 0 && status.RestartCount >= restartThreshold {
+			// the text stays the same as the container keeps restarting, so
+			// that the fingerprint of the failure does not change
+			restartMetadata := map[string]string{
+				"container":    status.Name,
+				"restartCount": fmt.Sprint(status.RestartCount),
+			}
+			if terminated := terminatedState(status); terminated != nil {
+				restartMetadata["lastExitCode"] = fmt.Sprint(terminated.ExitCode)
+				restartMetadata["lastTerminationMessage"] = terminationMessage(status)
+			}
+			containerFailures = append(containerFailures, Failure{
+				Text: fmt.Sprintf("%s %s has restarted at least %d times",
+					containerType, status.Name, restartThreshold),
+				Reason:   ReasonContainerRestarting,
+				Severity: SeverityWarning,
+				Metadata: restartMetadata,
+			})
+		}
+		failures = append(failures, containerFailures...)
+	}
+	return failures
+}
+
+// fetchFailureLogs returns the logs of the containers of a pod that are
+// crashing, once per container
+func fetchFailureLogs(ctx context.Context, client *kubernetes.Client, pod v1.Pod, failures []Failure, lines int64) []ContainerLog {
+	var logs []ContainerLog
+	fetched := map[string]bool{}
+	for _, failure := range failures {
+		container := failure.Metadata["container"]
+		if !podLogReasons[failure.Reason] || fetched[container] {
+			continue
+		}
+		fetched[container] = true
+		logs = append(logs, FetchContainerLogs(ctx, client, pod, container, lines)...)
+	}
+	return logs
+}
+
+// memoryLimit returns the memory limit of a container of a pod, if any
+func memoryLimit(pod v1.Pod, name string) string {
+	var containers []v1.Container
+	containers = append(containers, pod.Spec.InitContainers...)
+	containers = append(containers, pod.Spec.Containers...)
+	for _, container := range containers {
+		if container.Name != name {
+			continue
+		}
+		if limit, ok := container.Resources.Limits[v1.ResourceMemory]; ok {
+			return limit.String()
+		}
+	}
+	return ""
+}
@@ -2,11 +2,14 @@ package analyzer
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
 	"github.com/magiconair/properties/assert"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 )
@@ -43,3 +46,212 @@ func TestPodAnalzyer(t *testing.T) {
 
 	assert.Equal(t, len(analysisResults), 1)
 }
+
+func TestPodAnalyzerContainerFailures(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		spec       v1.PodSpec
+		status     v1.PodStatus
+		reasons    []string
+		containers []string
+	}{
+		{
+			name: "running",
+			status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{Name: "app", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+				},
+			},
+		},
+		{
+			name: "image pull error",
+			status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{Name: "app", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ErrImagePull"}}},
+				},
+			},
+			reasons:    []string{ReasonErrImagePull},
+			containers: []string{"app"},
+		},
+		{
+			name: "crash looping after OOMKilled",
+			spec: v1.PodSpec{
+				Containers: []v1.Container{{
+					Name: "app",
+					Resources: v1.ResourceRequirements{
+						Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("128Mi")},
+					},
+				}},
+			},
+			status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						Name:                 "app",
+						State:                v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 5m0s restarting failed container"}},
+						LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+						RestartCount:         12,
+					},
+				},
+			},
+			reasons:    []string{ReasonCrashLoopBackOff, ReasonContainerOOMKilled},
+			containers: []string{"app", "app"},
+		},
+		{
+			name: "init container failed",
+			status: v1.PodStatus{
+				InitContainerStatuses: []v1.ContainerStatus{
+					{Name: "migrate", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}}},
+				},
+			},
+			reasons:    []string{ReasonContainerTerminatedWithError},
+			containers: []string{"migrate"},
+		},
+		{
+			name: "config error in ephemeral container",
+			status: v1.PodStatus{
+				EphemeralContainerStatuses: []v1.ContainerStatus{
+					{Name: "debugger", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CreateContainerConfigError", Message: "secret \"token\" not found"}}},
+				},
+			},
+			reasons:    []string{ReasonCreateContainerConfigError},
+			containers: []string{"debugger"},
+		},
+		{
+			name: "restarting while running",
+			status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						Name:                 "app",
+						State:                v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+						LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 2, Reason: "Error"}},
+						RestartCount:         DefaultRestartThreshold,
+					},
+				},
+			},
+			reasons:    []string{ReasonContainerRestarting},
+			containers: []string{"app"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(&v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "example",
+					Namespace: "default",
+				},
+				Spec:   tt.spec,
+				Status: tt.status,
+			})
+
+			podAnalyzer := PodAnalyzer{}
+			var analysisResults []Analysis
+			err := podAnalyzer.RunAnalysis(context.Background(),
+				&AnalysisConfiguration{
+					Namespace: "default",
+				},
+				&kubernetes.Client{
+					Client: clientset,
+				}, nil, &analysisResults)
+			assert.Equal(t, err, nil)
+
+			var reasons, containers []string
+			for _, analysis := range analysisResults {
+				for _, failure := range analysis.Error {
+					reasons = append(reasons, failure.Reason)
+					containers = append(containers, failure.Metadata["container"])
+				}
+			}
+			assert.Equal(t, reasons, tt.reasons)
+			assert.Equal(t, containers, tt.containers)
+		})
+	}
+}
+
+func TestPodAnalyzerWithLogs(t *testing.T) {
+
+	clientset := fake.NewSimpleClientset(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example",
+			Namespace: "default",
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:                 "app",
+					State:                v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}},
+				},
+			},
+		},
+	})
+
+	podAnalyzer := PodAnalyzer{}
+	var analysisResults []Analysis
+	err := podAnalyzer.RunAnalysis(context.Background(),
+		&AnalysisConfiguration{
+			Namespace: "default",
+			WithLogs:  true,
+		},
+		&kubernetes.Client{
+			Client: clientset,
+		}, nil, &analysisResults)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(analysisResults), 1)
+
+	// the fake clientset returns the same log for the previous and current
+	// container, the container is only fetched once for both failures
+	assert.Equal(t, analysisResults[0].Logs, []ContainerLog{
+		{Container: "app", Previous: true, Text: "fake logs"},
+		{Container: "app", Text: "fake logs"},
+	})
+}
+
+func TestTruncateLog(t *testing.T) {
+
+	line := strings.Repeat("x", 99) + "\n"
+	truncated := truncateLog(strings.Repeat(line, 100))
+	assert.Equal(t, len(truncated) <= maxLogBytes, true)
+	assert.Equal(t, strings.HasPrefix(truncated, "x"), true)
+	assert.Equal(t, truncateLog("short"), "short")
+}
+
+func TestPodAnalyzerRestartFingerprint(t *testing.T) {
+
+	// the fingerprint of a restarting container does not change as it keeps
+	// restarting
+	var ids []string
+	for _, restartCount := range []int32{DefaultRestartThreshold, DefaultRestartThreshold + 1} {
+		clientset := fake.NewSimpleClientset(&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "example",
+				Namespace: "default",
+			},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						Name:                 "app",
+						State:                v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+						LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 2, Reason: "Error"}},
+						RestartCount:         restartCount,
+					},
+				},
+			},
+		})
+
+		var analysisResults []Analysis
+		err := RunAnalysis(context.Background(), []string{"Pod"},
+			&AnalysisConfiguration{
+				Namespace: "default",
+			},
+			&kubernetes.Client{
+				Client: clientset,
+			}, nil, &analysisResults)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, len(analysisResults), 1)
+		assert.Equal(t, analysisResults[0].Error[0].Metadata["restartCount"], fmt.Sprint(restartCount))
+		ids = append(ids, analysisResults[0].ID)
+	}
+	assert.Equal(t, ids[0], ids[1])
+}
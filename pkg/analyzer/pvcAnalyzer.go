@@ -7,7 +7,6 @@ import (
 	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
 	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
 	"github.com/k8sgpt-ai/k8sgpt/pkg/util"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type PvcAnalyzer struct{}
@@ -15,7 +14,7 @@ type PvcAnalyzer struct{}
 func (PvcAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguration, client *kubernetes.Client, aiClient ai.IAI, analysisResults *[]Analysis) error {
 
 	// search all namespaces for pods that are not running
-	list, err := client.GetClient().CoreV1().PersistentVolumeClaims(config.Namespace).List(ctx, metav1.ListOptions{})
+	list, err := client.GetClient().CoreV1().PersistentVolumeClaims(config.Namespace).List(ctx, config.ListOptions())
 	if err != nil {
 		return err
 	}
@@ -23,7 +22,10 @@ func (PvcAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguratio
 	var preAnalysis = map[string]PreAnalysis{}
 
 	for _, pvc := range list.Items {
-		var failures []string
+		if config.IsExcluded(pvc.Namespace) {
+			continue
+		}
+		var failures []Failure
 
 		// Check for empty rs
 		if pvc.Status.Phase == "Pending" {
@@ -34,7 +36,11 @@ func (PvcAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguratio
 				continue
 			}
 			if evt.Reason == "ProvisioningFailed" && evt.Message != "" {
-				failures = append(failures, evt.Message)
+				failures = append(failures, Failure{
+					Text:     evt.Message,
+					Reason:   ReasonPVCProvisioningFailed,
+					Severity: SeverityCritical,
+				})
 			}
 		}
 		if len(failures) > 0 {
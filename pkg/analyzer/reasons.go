@@ -0,0 +1,55 @@
+package analyzer
+
+// Reason codes identify the kind of a failure for tools consuming the JSON
+// output, they are stable while the failure text may change.
+const (
+	ReasonPodUnschedulable             = "PodUnschedulable"
+	ReasonCrashLoopBackOff             = "CrashLoopBackOff"
+	ReasonImagePullBackOff             = "ImagePullBackOff"
+	ReasonErrImagePull                 = "ErrImagePull"
+	ReasonInvalidImageName             = "InvalidImageName"
+	ReasonCreateContainerConfigError   = "CreateContainerConfigError"
+	ReasonContainerOOMKilled           = "ContainerOOMKilled"
+	ReasonContainerTerminatedWithError = "ContainerTerminatedWithError"
+	ReasonContainerRestarting          = "ContainerRestarting"
+	ReasonPodSandboxCreateFailed       = "PodSandboxCreateFailed"
+	ReasonReplicaSetCreateFailed       = "ReplicaSetCreateFailed"
+	ReasonPVCProvisioningFailed        = "PersistentVolumeClaimProvisioningFailed"
+	ReasonServiceNoEndpoints           = "ServiceNoEndpoints"
+	ReasonServiceNotReadyEndpoints     = "ServiceNotReadyEndpoints"
+	ReasonIngressClassNotSpecified     = "IngressClassNotSpecified"
+	ReasonIngressClassNotFound         = "IngressClassNotFound"
+	ReasonIngressBackendServiceMissing = "IngressBackendServiceMissing"
+	ReasonIngressTLSSecretMissing      = "IngressTLSSecretMissing"
+	ReasonHPAScaleTargetInvalid        = "HorizontalPodAutoscalerScaleTargetInvalid"
+	ReasonHPAScaleTargetMissing        = "HorizontalPodAutoscalerScaleTargetMissing"
+	ReasonPDBNoPods                    = "PodDisruptionBudgetNoPods"
+
+	ReasonDeploymentProgressDeadlineExceeded = "DeploymentProgressDeadlineExceeded"
+	ReasonDeploymentUnavailableReplicas      = "DeploymentUnavailableReplicas"
+	ReasonDeploymentReplicaFailure           = "DeploymentReplicaFailure"
+
+	ReasonStatefulSetRolloutStuck        = "StatefulSetRolloutStuck"
+	ReasonStatefulSetServiceMissing      = "StatefulSetServiceMissing"
+	ReasonStatefulSetServiceNotHeadless  = "StatefulSetServiceNotHeadless"
+	ReasonStatefulSetStorageClassMissing = "StatefulSetStorageClassMissing"
+
+	ReasonDaemonSetMisscheduled      = "DaemonSetMisscheduled"
+	ReasonDaemonSetNotReady          = "DaemonSetNotReady"
+	ReasonDaemonSetPodMissing        = "DaemonSetPodMissing"
+	ReasonDaemonSetTaintNotTolerated = "DaemonSetTaintNotTolerated"
+
+	ReasonNodeNotReady           = "NodeNotReady"
+	ReasonNodeMemoryPressure     = "NodeMemoryPressure"
+	ReasonNodeDiskPressure       = "NodeDiskPressure"
+	ReasonNodePIDPressure        = "NodePIDPressure"
+	ReasonNodeNetworkUnavailable = "NodeNetworkUnavailable"
+	ReasonNodeCordoned           = "NodeCordoned"
+	ReasonNodeAffectedPods       = "NodeAffectedPods"
+
+	ReasonJobBackoffLimitExceeded = "JobBackoffLimitExceeded"
+	ReasonJobDeadlineExceeded     = "JobDeadlineExceeded"
+	ReasonCronJobSuspended        = "CronJobSuspended"
+	ReasonCronJobInvalidSchedule  = "CronJobInvalidSchedule"
+	ReasonCronJobNotSucceeded     = "CronJobNotSucceeded"
+)
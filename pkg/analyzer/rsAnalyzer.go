@@ -7,7 +7,6 @@ import (
 	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
 	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
 	"github.com/k8sgpt-ai/k8sgpt/pkg/util"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type ReplicaSetAnalyzer struct{}
@@ -16,7 +15,7 @@ func (ReplicaSetAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfi
 	client *kubernetes.Client, aiClient ai.IAI, analysisResults *[]Analysis) error {
 
 	// search all namespaces for pods that are not running
-	list, err := client.GetClient().AppsV1().ReplicaSets(config.Namespace).List(ctx, metav1.ListOptions{})
+	list, err := client.GetClient().AppsV1().ReplicaSets(config.Namespace).List(ctx, config.ListOptions())
 	if err != nil {
 		return err
 	}
@@ -24,7 +23,10 @@ func (ReplicaSetAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfi
 	var preAnalysis = map[string]PreAnalysis{}
 
 	for _, rs := range list.Items {
-		var failures []string
+		if config.IsExcluded(rs.Namespace) {
+			continue
+		}
+		var failures []Failure
 
 		// Check for empty rs
 		if rs.Status.Replicas == 0 {
@@ -32,7 +34,11 @@ func (ReplicaSetAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfi
 			// Check through container status to check for crashes
 			for _, rsStatus := range rs.Status.Conditions {
 				if rsStatus.Type == "ReplicaFailure" && rsStatus.Reason == "FailedCreate" {
-					failures = []string{rsStatus.Message}
+					failures = []Failure{{
+						Text:     rsStatus.Message,
+						Reason:   ReasonReplicaSetCreateFailed,
+						Severity: SeverityCritical,
+					}}
 				}
 			}
 		}
@@ -0,0 +1,50 @@
+package analyzer
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ValidateSelector checks the label selector of the configuration, client-go
+// fake clientsets such as the ones of snapshots panic on invalid selectors
+func (c *AnalysisConfiguration) ValidateSelector() error {
+	if _, err := labels.Parse(c.Selector); err != nil {
+		return fmt.Errorf("invalid selector %q: %w", c.Selector, err)
+	}
+	return nil
+}
+
+// ListOptions returns the options of the lists of the namespaced objects
+// analyzed, restricted by the label selector and the given field selectors
+// and excluding the excluded namespaces
+func (c *AnalysisConfiguration) ListOptions(selectors ...fields.Selector) metav1.ListOptions {
+	for _, namespace := range c.ExcludeNamespaces {
+		selectors = append(selectors, fields.OneTermNotEqualSelector("metadata.namespace", namespace))
+	}
+	return metav1.ListOptions{
+		LabelSelector: c.Selector,
+		FieldSelector: fields.AndSelectors(selectors...).String(),
+	}
+}
+
+// ClusterListOptions returns the options of the lists of the cluster scoped
+// objects analyzed, which cannot be selected by namespace
+func (c *AnalysisConfiguration) ClusterListOptions() metav1.ListOptions {
+	return metav1.ListOptions{LabelSelector: c.Selector}
+}
+
+// IsExcluded reports whether the objects of namespace are excluded from the
+// analysis. The field selector of ListOptions already excludes them, except
+// with clients that ignore field selectors such as the fake clientset of
+// snapshots.
+func (c *AnalysisConfiguration) IsExcluded(namespace string) bool {
+	for _, excluded := range c.ExcludeNamespaces {
+		if namespace == excluded {
+			return true
+		}
+	}
+	return false
+}
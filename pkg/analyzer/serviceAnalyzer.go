@@ -17,7 +17,7 @@ func (ServiceAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfigur
 	analysisResults *[]Analysis) error {
 
 	// search all namespaces for pods that are not running
-	list, err := client.GetClient().CoreV1().Endpoints(config.Namespace).List(ctx, metav1.ListOptions{})
+	list, err := client.GetClient().CoreV1().Endpoints(config.Namespace).List(ctx, config.ListOptions())
 	if err != nil {
 		return err
 	}
@@ -25,7 +25,10 @@ func (ServiceAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfigur
 	var preAnalysis = map[string]PreAnalysis{}
 
 	for _, ep := range list.Items {
-		var failures []string
+		if config.IsExcluded(ep.Namespace) {
+			continue
+		}
+		var failures []Failure
 
 		// Check for empty service
 		if len(ep.Subsets) == 0 {
@@ -36,11 +39,20 @@ func (ServiceAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfigur
 			}
 
 			for k, v := range svc.Spec.Selector {
-				failures = append(failures, fmt.Sprintf("Service has no endpoints, expected label %s=%s", k, v))
+				failures = append(failures, Failure{
+					Text:     fmt.Sprintf("Service has no endpoints, expected label %s=%s", k, v),
+					Reason:   ReasonServiceNoEndpoints,
+					Severity: SeverityWarning,
+					Metadata: map[string]string{
+						"labelKey":   k,
+						"labelValue": v,
+					},
+				})
 			}
 		} else {
 			count := 0
 			pods := []string{}
+			objects := []ObjectReference{}
 
 			// Check through container status to check for crashes
 			for _, epSubset := range ep.Subsets {
@@ -48,8 +60,18 @@ func (ServiceAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfigur
 					for _, addresses := range epSubset.NotReadyAddresses {
 						count++
 						pods = append(pods, addresses.TargetRef.Kind+"/"+addresses.TargetRef.Name)
+						objects = append(objects, ObjectReference{
+							Kind:      addresses.TargetRef.Kind,
+							Namespace: addresses.TargetRef.Namespace,
+							Name:      addresses.TargetRef.Name,
+						})
 					}
-					failures = append(failures, fmt.Sprintf("Service has not ready endpoints, pods: %s, expected %d", pods, count))
+					failures = append(failures, Failure{
+						Text:     fmt.Sprintf("Service has not ready endpoints, pods: %s, expected %d", pods, count),
+						Reason:   ReasonServiceNotReadyEndpoints,
+						Severity: SeverityWarning,
+						Objects:  objects,
+					})
 				}
 			}
 		}
@@ -43,4 +43,6 @@ func TestServiceAnalzyer(t *testing.T) {
 		}, nil, &analysisResults)
 
 	assert.Equal(t, len(analysisResults), 1)
+	assert.Equal(t, analysisResults[0].Error[0].Reason, ReasonServiceNoEndpoints)
+	assert.Equal(t, analysisResults[0].Error[0].Metadata["labelKey"], "app")
 }
@@ -0,0 +1,69 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity ranks how urgently a failure needs attention
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityCritical
+)
+
+var severityNames = map[Severity]string{
+	SeverityInfo:     "info",
+	SeverityWarning:  "warning",
+	SeverityCritical: "critical",
+}
+
+func ParseSeverity(s string) (Severity, error) {
+	for severity, name := range severityNames {
+		if strings.EqualFold(s, name) {
+			return severity, nil
+		}
+	}
+	return SeverityInfo, fmt.Errorf("unknown severity %s, expected one of critical, warning, info", s)
+}
+
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Severity) UnmarshalText(text []byte) error {
+	severity, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = severity
+	return nil
+}
+
+// FilterSeverity removes the failures below min, and the results left
+// without any failure
+func FilterSeverity(analysisResults []Analysis, min Severity) []Analysis {
+	var filtered []Analysis
+	for _, analysis := range analysisResults {
+		var failures []Failure
+		for _, failure := range analysis.Error {
+			if failure.Severity >= min {
+				failures = append(failures, failure)
+			}
+		}
+		if len(failures) > 0 {
+			analysis.Error = failures
+			filtered = append(filtered, analysis)
+		}
+	}
+	return filtered
+}
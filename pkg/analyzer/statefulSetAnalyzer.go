@@ -0,0 +1,139 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/util"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type StatefulSetAnalyzer struct {
+	// RolloutThreshold is how long a rollout can run before it is reported
+	// as stuck, it defaults to DefaultUnavailableThreshold like the progress
+	// deadline of deployments
+	RolloutThreshold time.Duration
+}
+
+func (s StatefulSetAnalyzer) RunAnalysis(ctx context.Context, config *AnalysisConfiguration,
+	client *kubernetes.Client, aiClient ai.IAI, analysisResults *[]Analysis) error {
+
+	list, err := client.GetClient().AppsV1().StatefulSets(config.Namespace).List(ctx, config.ListOptions())
+	if err != nil {
+		return err
+	}
+
+	threshold := s.RolloutThreshold
+	if threshold == 0 {
+		threshold = DefaultUnavailableThreshold
+	}
+
+	var preAnalysis = map[string]PreAnalysis{}
+
+	for _, sts := range list.Items {
+		if config.IsExcluded(sts.Namespace) {
+			continue
+		}
+		var failures []Failure
+
+		// Check for ordered rollouts that are stuck on a pod that does not become ready
+		desired := int32(1)
+		if sts.Spec.Replicas != nil {
+			desired = *sts.Spec.Replicas
+		}
+		if sts.Status.UpdateRevision != "" && sts.Status.UpdateRevision != sts.Status.CurrentRevision &&
+			sts.Status.ReadyReplicas < desired {
+			// the rollout started when the controller revision it rolls out to
+			// was created, rollouts of unknown age are not reported
+			revision, err := client.GetClient().AppsV1().ControllerRevisions(sts.Namespace).Get(ctx,
+				sts.Status.UpdateRevision, metav1.GetOptions{})
+			if err == nil && config.now().Sub(revision.CreationTimestamp.Time) > threshold {
+				metadata := map[string]string{
+					"currentRevision": sts.Status.CurrentRevision,
+					"updateRevision":  sts.Status.UpdateRevision,
+					"readyReplicas":   fmt.Sprint(sts.Status.ReadyReplicas),
+					"desiredReplicas": fmt.Sprint(desired),
+				}
+				// parse the event log and append details
+				evt, err := FetchLatestEvent(ctx, client, sts.Namespace, sts.Name)
+				if err == nil && evt != nil && evt.Message != "" {
+					metadata["lastEvent"] = evt.Message
+				}
+				failures = append(failures, Failure{
+					Text: fmt.Sprintf("StatefulSet rollout from revision %s to %s has not completed for more than %s",
+						sts.Status.CurrentRevision, sts.Status.UpdateRevision, threshold),
+					Reason:   ReasonStatefulSetRolloutStuck,
+					Severity: SeverityCritical,
+					Metadata: metadata,
+				})
+			}
+		}
+
+		// Check the governing service exists and is headless
+		if sts.Spec.ServiceName != "" {
+			svc, err := client.GetClient().CoreV1().Services(sts.Namespace).Get(ctx, sts.Spec.ServiceName, metav1.GetOptions{})
+			if err != nil {
+				failures = append(failures, Failure{
+					Text:     fmt.Sprintf("StatefulSet uses the service %s/%s which does not exist.", sts.Namespace, sts.Spec.ServiceName),
+					Reason:   ReasonStatefulSetServiceMissing,
+					Severity: SeverityWarning,
+					Objects:  []ObjectReference{{Kind: "Service", Namespace: sts.Namespace, Name: sts.Spec.ServiceName}},
+				})
+			} else if svc.Spec.ClusterIP != v1.ClusterIPNone {
+				failures = append(failures, Failure{
+					Text:     fmt.Sprintf("StatefulSet uses the service %s/%s which is not headless.", sts.Namespace, sts.Spec.ServiceName),
+					Reason:   ReasonStatefulSetServiceNotHeadless,
+					Severity: SeverityWarning,
+					Objects:  []ObjectReference{{Kind: "Service", Namespace: sts.Namespace, Name: sts.Spec.ServiceName}},
+				})
+			}
+		}
+
+		// Check the storage classes of the volume claim templates exist
+		for _, template := range sts.Spec.VolumeClaimTemplates {
+			storageClassName := template.Spec.StorageClassName
+			// an unset storage class uses the default one
+			if storageClassName == nil || *storageClassName == "" {
+				continue
+			}
+			_, err := client.GetClient().StorageV1().StorageClasses().Get(ctx, *storageClassName, metav1.GetOptions{})
+			if err != nil {
+				failures = append(failures, Failure{
+					Text: fmt.Sprintf("StatefulSet volume claim template %s uses the storage class %s which does not exist.",
+						template.Name, *storageClassName),
+					Reason:   ReasonStatefulSetStorageClassMissing,
+					Severity: SeverityCritical,
+					Objects:  []ObjectReference{{Kind: "StorageClass", Name: *storageClassName}},
+					Metadata: map[string]string{
+						"volumeClaimTemplate": template.Name,
+					},
+				})
+			}
+		}
+
+		if len(failures) > 0 {
+			preAnalysis[fmt.Sprintf("%s/%s", sts.Namespace, sts.Name)] = PreAnalysis{
+				StatefulSet:    sts,
+				FailureDetails: failures,
+			}
+		}
+	}
+
+	for key, value := range preAnalysis {
+		var currentAnalysis = Analysis{
+			Kind:  "StatefulSet",
+			Name:  key,
+			Error: value.FailureDetails,
+		}
+
+		parent, _ := util.GetParent(client, value.StatefulSet.ObjectMeta)
+		currentAnalysis.ParentObject = parent
+		*analysisResults = append(*analysisResults, currentAnalysis)
+	}
+
+	return nil
+}
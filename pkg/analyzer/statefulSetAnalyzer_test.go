@@ -0,0 +1,136 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/magiconair/properties/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestStatefulSetAnalyzer(t *testing.T) {
+
+	replicas := int32(3)
+	storageClass := "fast"
+	missingStorageClass := "missing"
+
+	headless := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec:       v1.ServiceSpec{ClusterIP: v1.ClusterIPNone},
+	}
+	clusterIP := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec:       v1.ServiceSpec{ClusterIP: "10.0.0.1"},
+	}
+	fast := &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{Name: storageClass},
+	}
+	// the controller revisions rolled out to, created when the rollout started
+	oldRevision := &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-2", Namespace: "default",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour))},
+	}
+	newRevision := &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-3", Namespace: "default",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Minute))},
+	}
+
+	tests := []struct {
+		name         string
+		storageClass *string
+		status       appsv1.StatefulSetStatus
+		objects      []runtime.Object
+		reasons      []string
+	}{
+		{
+			name:         "healthy",
+			storageClass: &storageClass,
+			status:       appsv1.StatefulSetStatus{ReadyReplicas: 3, CurrentRevision: "example-1", UpdateRevision: "example-1"},
+			objects:      []runtime.Object{headless, fast},
+		},
+		{
+			name:         "stuck rollout",
+			storageClass: &storageClass,
+			status:       appsv1.StatefulSetStatus{ReadyReplicas: 2, CurrentRevision: "example-1", UpdateRevision: "example-2"},
+			objects:      []runtime.Object{headless, fast, oldRevision},
+			reasons:      []string{ReasonStatefulSetRolloutStuck},
+		},
+		{
+			name:         "rollout in progress",
+			storageClass: &storageClass,
+			status:       appsv1.StatefulSetStatus{ReadyReplicas: 2, CurrentRevision: "example-2", UpdateRevision: "example-3"},
+			objects:      []runtime.Object{headless, fast, oldRevision, newRevision},
+		},
+		{
+			name:         "missing service",
+			storageClass: &storageClass,
+			status:       appsv1.StatefulSetStatus{ReadyReplicas: 3},
+			objects:      []runtime.Object{fast},
+			reasons:      []string{ReasonStatefulSetServiceMissing},
+		},
+		{
+			name:         "service not headless",
+			storageClass: &storageClass,
+			status:       appsv1.StatefulSetStatus{ReadyReplicas: 3},
+			objects:      []runtime.Object{clusterIP, fast},
+			reasons:      []string{ReasonStatefulSetServiceNotHeadless},
+		},
+		{
+			name:         "missing storage class",
+			storageClass: &missingStorageClass,
+			status:       appsv1.StatefulSetStatus{ReadyReplicas: 3},
+			objects:      []runtime.Object{headless, fast},
+			reasons:      []string{ReasonStatefulSetStorageClassMissing},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(append(tt.objects, &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "example",
+					Namespace: "default",
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas:    &replicas,
+					ServiceName: "example",
+					VolumeClaimTemplates: []v1.PersistentVolumeClaim{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "data"},
+							Spec: v1.PersistentVolumeClaimSpec{
+								StorageClassName: tt.storageClass,
+							},
+						},
+					},
+				},
+				Status: tt.status,
+			})...)
+
+			statefulSetAnalyzer := StatefulSetAnalyzer{}
+			var analysisResults []Analysis
+			err := statefulSetAnalyzer.RunAnalysis(context.Background(),
+				&AnalysisConfiguration{
+					Namespace: "default",
+				},
+				&kubernetes.Client{
+					Client: clientset,
+				}, nil, &analysisResults)
+			assert.Equal(t, err, nil)
+
+			var reasons []string
+			for _, analysis := range analysisResults {
+				for _, failure := range analysis.Error {
+					reasons = append(reasons, failure.Reason)
+				}
+			}
+			assert.Equal(t, reasons, tt.reasons)
+		})
+	}
+}
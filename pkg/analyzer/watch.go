@@ -0,0 +1,271 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"k8s.io/client-go/informers"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+// DefaultWatchResync is how often all analyzers are re-run in watch mode,
+// even if no object changed, so that time based findings such as cordoned
+// nodes or missed schedules are reported
+const DefaultWatchResync = 5 * time.Minute
+
+// watchDebounce is how long changes are collected before the affected
+// analyzers are re-run
+var watchDebounce = time.Second
+
+type WatchEventType string
+
+const (
+	WatchAdded    WatchEventType = "Added"
+	WatchChanged  WatchEventType = "Changed"
+	WatchResolved WatchEventType = "Resolved"
+	WatchError    WatchEventType = "Error"
+)
+
+// WatchEvent is a finding that appeared, changed or was resolved. Err is
+// only set for WatchError events, when an analyzer failed.
+type WatchEvent struct {
+	Type     WatchEventType
+	Analysis Analysis
+	Err      error
+}
+
+type WatchOptions struct {
+	Filters     []string
+	MinSeverity Severity
+	// IgnoreRules suppress the known and accepted failures
+	IgnoreRules []IgnoreRule
+	// Resync overrides DefaultWatchResync when set
+	Resync time.Duration
+}
+
+// analyzerInformers returns the informers of the kinds an analyzer reads,
+// a change to any of them re-runs the analyzer
+var analyzerInformers = map[string]func(informers.SharedInformerFactory) []toolscache.SharedIndexInformer{
+	"Pod": func(f informers.SharedInformerFactory) []toolscache.SharedIndexInformer {
+		return []toolscache.SharedIndexInformer{f.Core().V1().Pods().Informer()}
+	},
+	"ReplicaSet": func(f informers.SharedInformerFactory) []toolscache.SharedIndexInformer {
+		return []toolscache.SharedIndexInformer{f.Apps().V1().ReplicaSets().Informer()}
+	},
+	"PersistentVolumeClaim": func(f informers.SharedInformerFactory) []toolscache.SharedIndexInformer {
+		return []toolscache.SharedIndexInformer{f.Core().V1().PersistentVolumeClaims().Informer()}
+	},
+	"Service": func(f informers.SharedInformerFactory) []toolscache.SharedIndexInformer {
+		return []toolscache.SharedIndexInformer{
+			f.Core().V1().Services().Informer(),
+			f.Core().V1().Endpoints().Informer(),
+		}
+	},
+	"Ingress": func(f informers.SharedInformerFactory) []toolscache.SharedIndexInformer {
+		return []toolscache.SharedIndexInformer{
+			f.Networking().V1().Ingresses().Informer(),
+			f.Networking().V1().IngressClasses().Informer(),
+			f.Core().V1().Services().Informer(),
+		}
+	},
+	"Deployment": func(f informers.SharedInformerFactory) []toolscache.SharedIndexInformer {
+		return []toolscache.SharedIndexInformer{f.Apps().V1().Deployments().Informer()}
+	},
+	"StatefulSet": func(f informers.SharedInformerFactory) []toolscache.SharedIndexInformer {
+		return []toolscache.SharedIndexInformer{
+			f.Apps().V1().StatefulSets().Informer(),
+			f.Core().V1().Services().Informer(),
+		}
+	},
+	"DaemonSet": func(f informers.SharedInformerFactory) []toolscache.SharedIndexInformer {
+		return []toolscache.SharedIndexInformer{
+			f.Apps().V1().DaemonSets().Informer(),
+			f.Core().V1().Pods().Informer(),
+			f.Core().V1().Nodes().Informer(),
+		}
+	},
+	"Node": func(f informers.SharedInformerFactory) []toolscache.SharedIndexInformer {
+		return []toolscache.SharedIndexInformer{f.Core().V1().Nodes().Informer()}
+	},
+	"HorizontalPodAutoScaler": func(f informers.SharedInformerFactory) []toolscache.SharedIndexInformer {
+		return []toolscache.SharedIndexInformer{f.Autoscaling().V1().HorizontalPodAutoscalers().Informer()}
+	},
+	"PodDisruptionBudget": func(f informers.SharedInformerFactory) []toolscache.SharedIndexInformer {
+		return []toolscache.SharedIndexInformer{
+			f.Policy().V1().PodDisruptionBudgets().Informer(),
+			f.Core().V1().Pods().Informer(),
+		}
+	},
+	"Job": func(f informers.SharedInformerFactory) []toolscache.SharedIndexInformer {
+		return []toolscache.SharedIndexInformer{f.Batch().V1().Jobs().Informer()}
+	},
+	"CronJob": func(f informers.SharedInformerFactory) []toolscache.SharedIndexInformer {
+		return []toolscache.SharedIndexInformer{f.Batch().V1().CronJobs().Informer()}
+	},
+}
+
+// Watch runs the selected analyzers, then re-runs an analyzer whenever an
+// object it reads changes, and calls handler for each finding that appears,
+// changes or is resolved. The analyzers read the objects from the caches of
+// the informers. Findings are explained first when config.Explain
+// is set. Watch blocks until ctx is done.
+func Watch(ctx context.Context, options WatchOptions, config *AnalysisConfiguration,
+	client *kubernetes.Client, aiClient ai.IAI, handler func(WatchEvent)) error {
+
+	resync := options.Resync
+	if resync == 0 {
+		resync = DefaultWatchResync
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(client.GetClient(), 0,
+		informers.WithNamespace(config.Namespace))
+
+	// changed receives the analyzers to re-run from the informer handlers
+	changed := make(chan string, 100)
+	var analyzers []string
+	for _, filter := range selectFilters(options.Filters) {
+		newInformers, ok := analyzerInformers[filter]
+		if !ok {
+			continue
+		}
+		analyzers = append(analyzers, filter)
+		for _, informer := range newInformers(factory) {
+			if _, err := informer.AddEventHandler(analyzerEventHandler(ctx, filter, changed)); err != nil {
+				return err
+			}
+		}
+	}
+	if len(analyzers) == 0 {
+		return fmt.Errorf("no analyzer to watch, valid filters are the ones listed by k8sgpt filters list")
+	}
+
+	factory.Start(ctx.Done())
+	for informerType, ok := range factory.WaitForCacheSync(ctx.Done()) {
+		if !ok {
+			return fmt.Errorf("error syncing %v informer", informerType)
+		}
+	}
+
+	// the analyzers read from the informers instead of listing the objects
+	// again from the API server on every change
+	cachedClient := kubernetes.NewCachedClient(client, factory, config.Namespace, ctx.Done())
+
+	// findings holds the current results of each analyzer by kind and name
+	findings := map[string]map[string]Analysis{}
+	run := func(filters []string) {
+		for _, filter := range filters {
+			runWatchedAnalyzer(ctx, filter, options, config, cachedClient, aiClient, findings, handler)
+		}
+		var current []Analysis
+		for _, analyses := range findings {
+			for _, analysis := range analyses {
+				current = append(current, analysis)
+			}
+		}
+		RecordFindings(current)
+	}
+	run(analyzers)
+
+	dirty := map[string]bool{}
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+	ticker := time.NewTicker(resync)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case filter := <-changed:
+			if len(dirty) == 0 {
+				debounce.Reset(watchDebounce)
+			}
+			dirty[filter] = true
+		case <-debounce.C:
+			var filters []string
+			for filter := range dirty {
+				filters = append(filters, filter)
+			}
+			sort.Strings(filters)
+			dirty = map[string]bool{}
+			run(filters)
+		case <-ticker.C:
+			run(analyzers)
+		}
+	}
+}
+
+func analyzerEventHandler(ctx context.Context, filter string, changed chan<- string) toolscache.ResourceEventHandler {
+	notify := func() {
+		select {
+		case changed <- filter:
+		case <-ctx.Done():
+		}
+	}
+	return toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { notify() },
+		UpdateFunc: func(oldObj, newObj interface{}) { notify() },
+		DeleteFunc: func(obj interface{}) { notify() },
+	}
+}
+
+// runWatchedAnalyzer re-runs an analyzer and reports the differences with
+// its previous findings
+func runWatchedAnalyzer(ctx context.Context, filter string, options WatchOptions, config *AnalysisConfiguration,
+	client *kubernetes.Client, aiClient ai.IAI, findings map[string]map[string]Analysis, handler func(WatchEvent)) {
+
+	var results []Analysis
+	if err := RunAnalysis(ctx, []string{filter}, config, client, aiClient, &results); err != nil {
+		handler(WatchEvent{Type: WatchError, Err: err})
+		return
+	}
+	results = FilterSeverity(results, options.MinSeverity)
+	results, _ = ApplyIgnoreRules(results, options.IgnoreRules, time.Now())
+
+	previous := findings[filter]
+	current := map[string]Analysis{}
+	var events []WatchEvent
+	for _, analysis := range results {
+		key := analysis.Kind + "/" + analysis.Name
+		current[key] = analysis
+		old, ok := previous[key]
+		switch {
+		case !ok:
+			events = append(events, WatchEvent{Type: WatchAdded, Analysis: analysis})
+		case old.ID != analysis.ID:
+			events = append(events, WatchEvent{Type: WatchChanged, Analysis: analysis})
+		}
+	}
+
+	if config.Explain && aiClient != nil && len(events) > 0 {
+		explained := make([]Analysis, len(events))
+		for i, event := range events {
+			explained[i] = event.Analysis
+		}
+		if err := ExplainAnalysis(ctx, config, aiClient, explained, nil); err != nil {
+			handler(WatchEvent{Type: WatchError, Err: err})
+		}
+		for i := range events {
+			events[i].Analysis = explained[i]
+		}
+	}
+
+	var resolved []string
+	for key := range previous {
+		if _, ok := current[key]; !ok {
+			resolved = append(resolved, key)
+		}
+	}
+	sort.Strings(resolved)
+	for _, key := range resolved {
+		events = append(events, WatchEvent{Type: WatchResolved, Analysis: previous[key]})
+	}
+
+	findings[filter] = current
+	for _, event := range events {
+		handler(event)
+	}
+}
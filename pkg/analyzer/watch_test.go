@@ -0,0 +1,86 @@
+package analyzer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/magiconair/properties/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWatch(t *testing.T) {
+
+	watchDebounce = 10 * time.Millisecond
+	defer func() { watchDebounce = time.Second }()
+
+	clientset := fake.NewSimpleClientset(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "app", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ErrImagePull"}}},
+			},
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan WatchEvent, 10)
+	done := make(chan error)
+	go func() {
+		done <- Watch(ctx, WatchOptions{Filters: []string{"Pod"}},
+			&AnalysisConfiguration{Namespace: "default"},
+			&kubernetes.Client{Client: clientset}, nil, func(event WatchEvent) {
+				events <- event
+			})
+	}()
+
+	next := func() WatchEvent {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a watch event")
+			return WatchEvent{}
+		}
+	}
+
+	event := next()
+	assert.Equal(t, event.Type, WatchAdded)
+	assert.Equal(t, event.Analysis.Name, "default/example")
+
+	// the pod now crashes after pulling the image
+	pod, err := clientset.CoreV1().Pods("default").Get(ctx, "example", metav1.GetOptions{})
+	assert.Equal(t, err, nil)
+	pod.Status.ContainerStatuses[0].State = v1.ContainerState{
+		Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+	}
+	_, err = clientset.CoreV1().Pods("default").UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+	assert.Equal(t, err, nil)
+
+	event = next()
+	assert.Equal(t, event.Type, WatchChanged)
+	assert.Equal(t, event.Analysis.Error[0].Reason, ReasonCrashLoopBackOff)
+
+	err = clientset.CoreV1().Pods("default").Delete(ctx, "example", metav1.DeleteOptions{})
+	assert.Equal(t, err, nil)
+
+	event = next()
+	assert.Equal(t, event.Type, WatchResolved)
+	assert.Equal(t, event.Analysis.Name, "default/example")
+
+	cancel()
+	assert.Equal(t, <-done, nil)
+
+	// the analyzer read the pods from the informer, which listed them once
+	lists := 0
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == "list" && action.GetResource().Resource == "pods" {
+			lists++
+		}
+	}
+	assert.Equal(t, lists, 1)
+}
@@ -0,0 +1,207 @@
+package anonymizer
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Placeholder kinds, a value is replaced by <kind-n>
+const (
+	KindName  = "name"
+	KindEmail = "email"
+	KindImage = "image"
+	KindIP    = "ip"
+	KindToken = "token"
+)
+
+// minTokenLength is the length from which a string of letters and digits is
+// considered a secret
+const minTokenLength = 32
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`)
+	jwtPattern   = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	// image references are only matched when they name a registry
+	imagePattern = regexp.MustCompile(`(?:[A-Za-z0-9-]+\.)+[A-Za-z]{2,}(?::[0-9]+)?/[A-Za-z0-9._/-]+(?::[A-Za-z0-9._-]+)?(?:@sha256:[a-f0-9]{64})?`)
+	ipv4Pattern  = regexp.MustCompile(`\b(?:[0-9]{1,3}\.){3}[0-9]{1,3}\b`)
+	ipv6Pattern  = regexp.MustCompile(`\b(?:[0-9A-Fa-f]{1,4}:){2,7}[0-9A-Fa-f]{1,4}\b|\b(?:[0-9A-Fa-f]{1,4}:){1,7}:(?:[0-9A-Fa-f]{1,4}\b)?`)
+	tokenPattern = regexp.MustCompile(fmt.Sprintf(`[A-Za-z0-9+/_-]{%d,}={0,2}`, minTokenLength))
+)
+
+// wellKnownDomains are the prefixes of label and taint keys that look like
+// image references but are needed to understand a failure
+var wellKnownDomains = []string{"kubernetes.io/", "k8s.io/"}
+
+// Anonymizer replaces sensitive values in a text with placeholders and maps
+// them back. The same value is always replaced by the same placeholder, so
+// that a text can be masked, sent to the AI backend and the response
+// unmasked with the same Anonymizer.
+type Anonymizer struct {
+	mu           sync.Mutex
+	names        []string
+	placeholders map[string]string
+	values       map[string]string
+	counts       map[string]int
+}
+
+func New() *Anonymizer {
+	return &Anonymizer{
+		placeholders: map[string]string{},
+		values:       map[string]string{},
+		counts:       map[string]int{},
+	}
+}
+
+// AddNames registers object names to mask, such as namespaces, pods or
+// containers. Names are only masked as whole words.
+func (a *Anonymizer) AddNames(names ...string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, name := range names {
+		if name != "" {
+			a.names = append(a.names, name)
+		}
+	}
+	// longer names first, so that a name containing another one is masked
+	// as a whole
+	sort.SliceStable(a.names, func(i, j int) bool {
+		return len(a.names[i]) > len(a.names[j])
+	})
+}
+
+type match struct {
+	start, end int
+	kind       string
+}
+
+// Mask replaces the registered names, emails, image references, IPs and
+// token-like strings found in text with placeholders
+func (a *Anonymizer) Mask(text string) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// matches are collected on the original text in order of priority, a
+	// match overlapping an earlier one is dropped
+	var matches []match
+	add := func(start, end int, kind string) {
+		for _, m := range matches {
+			if start < m.end && m.start < end {
+				return
+			}
+		}
+		matches = append(matches, match{start, end, kind})
+	}
+	addPattern := func(pattern *regexp.Regexp, kind string, valid func(string) bool) {
+		for _, loc := range pattern.FindAllStringIndex(text, -1) {
+			if valid == nil || valid(text[loc[0]:loc[1]]) {
+				add(loc[0], loc[1], kind)
+			}
+		}
+	}
+
+	addPattern(jwtPattern, KindToken, nil)
+	addPattern(emailPattern, KindEmail, nil)
+	addPattern(imagePattern, KindImage, isImage)
+	addPattern(ipv4Pattern, KindIP, isIP)
+	addPattern(ipv6Pattern, KindIP, isIP)
+	for _, name := range a.names {
+		for _, loc := range findWords(text, name) {
+			add(loc[0], loc[1], KindName)
+		}
+	}
+	addPattern(tokenPattern, KindToken, isToken)
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].start < matches[j].start
+	})
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		b.WriteString(text[last:m.start])
+		b.WriteString(a.placeholder(m.kind, text[m.start:m.end]))
+		last = m.end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// Unmask replaces the placeholders found in text with their original values
+func (a *Anonymizer) Unmask(text string) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(a.values) == 0 {
+		return text
+	}
+	replacements := make([]string, 0, 2*len(a.values))
+	for placeholder, value := range a.values {
+		replacements = append(replacements, placeholder, value)
+	}
+	return strings.NewReplacer(replacements...).Replace(text)
+}
+
+// placeholder returns the placeholder of a value, creating it if needed
+func (a *Anonymizer) placeholder(kind string, value string) string {
+	if placeholder, ok := a.placeholders[value]; ok {
+		return placeholder
+	}
+	a.counts[kind]++
+	placeholder := fmt.Sprintf("<%s-%d>", kind, a.counts[kind])
+	a.placeholders[value] = placeholder
+	a.values[placeholder] = value
+	return placeholder
+}
+
+// findWords returns the locations of word in text that are not part of a
+// longer name
+func findWords(text string, word string) [][2]int {
+	var locs [][2]int
+	for offset := 0; offset < len(text); {
+		i := strings.Index(text[offset:], word)
+		if i < 0 {
+			break
+		}
+		start, end := offset+i, offset+i+len(word)
+		if (start == 0 || !isNameChar(text[start-1])) && (end == len(text) || !isNameChar(text[end])) {
+			locs = append(locs, [2]int{start, end})
+		}
+		offset = start + 1
+	}
+	return locs
+}
+
+func isNameChar(c byte) bool {
+	return c == '-' || c == '_' || c < unicode.MaxASCII && (unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)))
+}
+
+func isImage(s string) bool {
+	for _, domain := range wellKnownDomains {
+		if strings.HasPrefix(s, domain) || strings.Contains(s, "."+domain) {
+			return false
+		}
+	}
+	return true
+}
+
+func isIP(s string) bool {
+	return net.ParseIP(s) != nil
+}
+
+// isToken reports whether a long string mixes letters and digits, as keys
+// and hashes do, unlike long hyphenated words and file paths
+func isToken(s string) bool {
+	if strings.HasPrefix(s, "/") {
+		return false
+	}
+	var letters, digits bool
+	for _, c := range s {
+		letters = letters || unicode.IsLetter(c)
+		digits = digits || unicode.IsDigit(c)
+	}
+	return letters && digits
+}
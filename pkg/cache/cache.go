@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+type ICache interface {
+	// Get returns the entry stored under key, ok is false if there is no
+	// entry or it has expired
+	Get(key string) (entry Entry, ok bool, err error)
+	Put(entry Entry) error
+	Remove(key string) error
+	List() ([]Entry, error)
+	Stats() (Stats, error)
+	// Purge removes all entries without reading them, so that a corrupted
+	// cache can be purged, and returns the number of entries removed
+	Purge() (int, error)
+}
+
+// Entry is an AI response stored in the cache
+type Entry struct {
+	Key       string    `json:"key"`
+	Backend   string    `json:"backend"`
+	Model     string    `json:"model"`
+	Response  string    `json:"response"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type Stats struct {
+	Location string
+	Entries  int
+	Expired  int
+	Size     int64
+}
+
+// Key returns the cache key of the response to prompt from a backend and model
+func Key(backend string, model string, prompt string) string {
+	hash := sha256.New()
+	for _, s := range []string{backend, model, prompt} {
+		hash.Write([]byte(s))
+		// separate the fields so that they cannot run into each other
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,28 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultTTL is how long responses are cached when cache_ttl is not set
+const DefaultTTL = 7 * 24 * time.Hour
+
+// NewFromConfig returns the file cache configured by cache_dir and cache_ttl
+// in the config file
+func NewFromConfig() (*FileCache, error) {
+	dir := viper.GetString("cache_dir")
+	if dir == "" {
+		var err error
+		dir, err = DefaultDir()
+		if err != nil {
+			return nil, err
+		}
+	}
+	ttl := DefaultTTL
+	if viper.IsSet("cache_ttl") {
+		ttl = viper.GetDuration("cache_ttl")
+	}
+	return NewFileCache(dir, ttl)
+}
@@ -0,0 +1,178 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const fileExtension = ".json"
+
+// tmpExtension is the extension of the files entries are written to before
+// being renamed
+const tmpExtension = ".tmp"
+
+// FileCache stores one JSON file per entry in a directory
+type FileCache struct {
+	dir string
+	// ttl is how long entries are kept, zero keeps them forever
+	ttl time.Duration
+}
+
+// DefaultDir returns the k8sgpt directory in the user cache directory,
+// $XDG_CACHE_HOME/k8sgpt on Linux
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "k8sgpt"), nil
+}
+
+func NewFileCache(dir string, ttl time.Duration) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &FileCache{
+		dir: dir,
+		ttl: ttl,
+	}, nil
+}
+
+func (c *FileCache) Get(key string) (Entry, bool, error) {
+	entry, err := c.read(c.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return Entry{}, false, nil
+	}
+	if err != nil {
+		return Entry{}, false, err
+	}
+	if c.expired(entry) {
+		return Entry{}, false, c.Remove(key)
+	}
+	return entry, true, nil
+}
+
+func (c *FileCache) Put(entry Entry) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	// write to a temporary file first so that readers never see a partial entry
+	tmp, err := os.CreateTemp(c.dir, entry.Key+".*"+tmpExtension)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(entry.Key))
+}
+
+func (c *FileCache) Remove(key string) error {
+	err := os.Remove(c.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// List returns all entries, including expired ones that have not been
+// removed yet
+func (c *FileCache) List() ([]Entry, error) {
+	files, err := c.files()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(files))
+	for _, file := range files {
+		entry, err := c.read(file)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (c *FileCache) Stats() (Stats, error) {
+	stats := Stats{Location: c.dir}
+	files, err := c.files()
+	if err != nil {
+		return stats, err
+	}
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return stats, err
+		}
+		entry, err := c.read(file)
+		if err != nil {
+			return stats, err
+		}
+		stats.Entries++
+		stats.Size += info.Size()
+		if c.expired(entry) {
+			stats.Expired++
+		}
+	}
+	return stats, nil
+}
+
+// Purge removes the entries and the temporary files left by interrupted
+// writes
+func (c *FileCache) Purge() (int, error) {
+	files, err := c.files()
+	if err != nil {
+		return 0, err
+	}
+	tmpFiles, err := filepath.Glob(filepath.Join(c.dir, "*"+tmpExtension))
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, file := range append(files, tmpFiles...) {
+		if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return removed, err
+		}
+		if filepath.Ext(file) == fileExtension {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func (c *FileCache) expired(entry Entry) bool {
+	return c.ttl > 0 && time.Since(entry.CreatedAt) > c.ttl
+}
+
+func (c *FileCache) path(key string) string {
+	return filepath.Join(c.dir, key+fileExtension)
+}
+
+func (c *FileCache) files() ([]string, error) {
+	return filepath.Glob(filepath.Join(c.dir, "*"+fileExtension))
+}
+
+func (c *FileCache) read(path string) (Entry, error) {
+	var entry Entry
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return entry, err
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return entry, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestFileCache(t *testing.T) {
+
+	c, err := NewFileCache(t.TempDir(), time.Hour)
+	assert.Equal(t, err, nil)
+
+	key := Key("openai", "gpt-3.5-turbo", "prompt")
+	assert.Equal(t, key != Key("openai", "gpt-4", "prompt"), true)
+
+	_, ok, err := c.Get(key)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, ok, false)
+
+	err = c.Put(Entry{Key: key, Backend: "openai", Model: "gpt-3.5-turbo", Response: "response"})
+	assert.Equal(t, err, nil)
+
+	entry, ok, err := c.Get(key)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, entry.Response, "response")
+
+	// entries older than the TTL are expired
+	err = c.Put(Entry{Key: "expired", Response: "old", CreatedAt: time.Now().Add(-2 * time.Hour)})
+	assert.Equal(t, err, nil)
+
+	stats, err := c.Stats()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, stats.Entries, 2)
+	assert.Equal(t, stats.Expired, 1)
+
+	_, ok, err = c.Get("expired")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, ok, false)
+
+	err = c.Remove(key)
+	assert.Equal(t, err, nil)
+
+	entries, err := c.List()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(entries), 0)
+}
+
+func TestFileCachePurge(t *testing.T) {
+
+	dir := t.TempDir()
+	c, err := NewFileCache(dir, time.Hour)
+	assert.Equal(t, err, nil)
+
+	err = c.Put(Entry{Key: Key("openai", "gpt-4", "prompt"), Response: "response"})
+	assert.Equal(t, err, nil)
+	// a corrupted entry and the leftover of an interrupted write
+	err = os.WriteFile(filepath.Join(dir, "corrupted.json"), []byte("{"), 0600)
+	assert.Equal(t, err, nil)
+	err = os.WriteFile(filepath.Join(dir, "key.123.tmp"), []byte("{"), 0600)
+	assert.Equal(t, err, nil)
+
+	_, err = c.List()
+	assert.Equal(t, err != nil, true)
+
+	removed, err := c.Purge()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, removed, 2)
+
+	files, err := os.ReadDir(dir)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(files), 0)
+}
@@ -0,0 +1,731 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	appsv1client "k8s.io/client-go/kubernetes/typed/apps/v1"
+	autoscalingv1client "k8s.io/client-go/kubernetes/typed/autoscaling/v1"
+	batchv1client "k8s.io/client-go/kubernetes/typed/batch/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	networkingv1client "k8s.io/client-go/kubernetes/typed/networking/v1"
+	policyv1client "k8s.io/client-go/kubernetes/typed/policy/v1"
+	storagev1client "k8s.io/client-go/kubernetes/typed/storage/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NewCachedClient returns a client serving the lists and gets of the
+// resources read by the analyzers from the informers of factory, which
+// must be scoped to namespace. Informers are started on first use and
+// stopped with stopCh. Reads of other namespaces, with field selectors
+// the cache cannot evaluate, of secrets or logs, and writes go to client.
+func NewCachedClient(client *Client, factory informers.SharedInformerFactory, namespace string,
+	stopCh <-chan struct{}) *Client {
+
+	return &Client{Client: &cachedClientset{
+		Interface: client.GetClient(),
+		cache:     &informerCache{factory: factory, namespace: namespace, stopCh: stopCh},
+	}}
+}
+
+type informerCache struct {
+	factory   informers.SharedInformerFactory
+	namespace string
+	stopCh    <-chan struct{}
+}
+
+// covers reports whether the informers hold the objects of namespace
+func (c *informerCache) covers(namespace string, clusterScoped bool) bool {
+	return clusterScoped || c.namespace == "" || c.namespace == namespace
+}
+
+// indexer starts informer if it is new and waits for it to be synced
+func (c *informerCache) indexer(ctx context.Context, informer cache.SharedIndexInformer) (cache.Indexer, error) {
+	c.factory.Start(c.stopCh)
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		return nil, fmt.Errorf("error syncing informer: %w", context.Cause(ctx))
+	}
+	return informer.GetIndexer(), nil
+}
+
+// objectFields returns the fields every object can be selected on
+func objectFields(obj metav1.Object) fields.Set {
+	return fields.Set{
+		"metadata.name":      obj.GetName(),
+		"metadata.namespace": obj.GetNamespace(),
+	}
+}
+
+// cachedList returns copies of the objects of the informer matching opts,
+// or false if the cache cannot serve the list
+func cachedList[T any, PT interface {
+	*T
+	runtime.Object
+}](ctx context.Context, c *informerCache, informer cache.SharedIndexInformer, namespace string,
+	clusterScoped bool, opts metav1.ListOptions, objFields func(PT) fields.Set) ([]T, bool, error) {
+
+	if !c.covers(namespace, clusterScoped) {
+		return nil, false, nil
+	}
+	labelSelector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, false, nil
+	}
+	fieldSelector, err := fields.ParseSelector(opts.FieldSelector)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	indexer, err := c.indexer(ctx, informer)
+	if err != nil {
+		return nil, true, err
+	}
+	var objs []interface{}
+	if namespace == "" {
+		objs = indexer.List()
+	} else if objs, err = indexer.ByIndex(cache.NamespaceIndex, namespace); err != nil {
+		return nil, true, err
+	}
+
+	var items []T
+	for _, obj := range objs {
+		item := obj.(PT)
+		accessor, err := meta.Accessor(item)
+		if err != nil {
+			return nil, true, err
+		}
+		if !labelSelector.Matches(labels.Set(accessor.GetLabels())) {
+			continue
+		}
+		set := objectFields(accessor)
+		if objFields != nil {
+			set = objFields(item)
+		}
+		for _, requirement := range fieldSelector.Requirements() {
+			if _, ok := set[requirement.Field]; !ok {
+				return nil, false, nil
+			}
+		}
+		if !fieldSelector.Matches(set) {
+			continue
+		}
+		// the objects of the informer are shared and must not be modified
+		items = append(items, *item.DeepCopyObject().(PT))
+	}
+	return items, true, nil
+}
+
+// cachedGet returns a copy of an object of the informer
+func cachedGet[T any, PT interface {
+	*T
+	runtime.Object
+}](ctx context.Context, c *informerCache, informer cache.SharedIndexInformer, resource schema.GroupResource,
+	namespace string, clusterScoped bool, name string) (PT, bool, error) {
+
+	if !c.covers(namespace, clusterScoped) {
+		return nil, false, nil
+	}
+	indexer, err := c.indexer(ctx, informer)
+	if err != nil {
+		return nil, true, err
+	}
+	key := name
+	if namespace != "" {
+		key = namespace + "/" + name
+	}
+	obj, exists, err := indexer.GetByKey(key)
+	if err != nil {
+		return nil, true, err
+	}
+	if !exists {
+		return nil, true, apierrors.NewNotFound(resource, name)
+	}
+	return obj.(PT).DeepCopyObject().(PT), true, nil
+}
+
+type cachedClientset struct {
+	kubernetes.Interface
+	cache *informerCache
+}
+
+func (c *cachedClientset) CoreV1() corev1client.CoreV1Interface {
+	return cachedCoreV1{CoreV1Interface: c.Interface.CoreV1(), cache: c.cache}
+}
+
+func (c *cachedClientset) AppsV1() appsv1client.AppsV1Interface {
+	return cachedAppsV1{AppsV1Interface: c.Interface.AppsV1(), cache: c.cache}
+}
+
+func (c *cachedClientset) BatchV1() batchv1client.BatchV1Interface {
+	return cachedBatchV1{BatchV1Interface: c.Interface.BatchV1(), cache: c.cache}
+}
+
+func (c *cachedClientset) NetworkingV1() networkingv1client.NetworkingV1Interface {
+	return cachedNetworkingV1{NetworkingV1Interface: c.Interface.NetworkingV1(), cache: c.cache}
+}
+
+func (c *cachedClientset) StorageV1() storagev1client.StorageV1Interface {
+	return cachedStorageV1{StorageV1Interface: c.Interface.StorageV1(), cache: c.cache}
+}
+
+func (c *cachedClientset) AutoscalingV1() autoscalingv1client.AutoscalingV1Interface {
+	return cachedAutoscalingV1{AutoscalingV1Interface: c.Interface.AutoscalingV1(), cache: c.cache}
+}
+
+func (c *cachedClientset) PolicyV1() policyv1client.PolicyV1Interface {
+	return cachedPolicyV1{PolicyV1Interface: c.Interface.PolicyV1(), cache: c.cache}
+}
+
+// core/v1, secrets are not cached so that their data is not kept in memory
+
+type cachedCoreV1 struct {
+	corev1client.CoreV1Interface
+	cache *informerCache
+}
+
+func (c cachedCoreV1) Pods(namespace string) corev1client.PodInterface {
+	return cachedPods{c.CoreV1Interface.Pods(namespace), c.cache, namespace}
+}
+
+func (c cachedCoreV1) Events(namespace string) corev1client.EventInterface {
+	return cachedEvents{c.CoreV1Interface.Events(namespace), c.cache, namespace}
+}
+
+func (c cachedCoreV1) Services(namespace string) corev1client.ServiceInterface {
+	return cachedServices{c.CoreV1Interface.Services(namespace), c.cache, namespace}
+}
+
+func (c cachedCoreV1) Endpoints(namespace string) corev1client.EndpointsInterface {
+	return cachedEndpoints{c.CoreV1Interface.Endpoints(namespace), c.cache, namespace}
+}
+
+func (c cachedCoreV1) PersistentVolumeClaims(namespace string) corev1client.PersistentVolumeClaimInterface {
+	return cachedPersistentVolumeClaims{c.CoreV1Interface.PersistentVolumeClaims(namespace), c.cache, namespace}
+}
+
+func (c cachedCoreV1) ReplicationControllers(namespace string) corev1client.ReplicationControllerInterface {
+	return cachedReplicationControllers{c.CoreV1Interface.ReplicationControllers(namespace), c.cache, namespace}
+}
+
+func (c cachedCoreV1) Nodes() corev1client.NodeInterface {
+	return cachedNodes{c.CoreV1Interface.Nodes(), c.cache}
+}
+
+type cachedPods struct {
+	corev1client.PodInterface
+	cache     *informerCache
+	namespace string
+}
+
+func (c cachedPods) informer() cache.SharedIndexInformer {
+	return c.cache.factory.Core().V1().Pods().Informer()
+}
+
+func (c cachedPods) List(ctx context.Context, opts metav1.ListOptions) (*v1.PodList, error) {
+	items, ok, err := cachedList[v1.Pod](ctx, c.cache, c.informer(), c.namespace, false, opts, PodFields)
+	if !ok {
+		return c.PodInterface.List(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v1.PodList{Items: items}, nil
+}
+
+func (c cachedPods) Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.Pod, error) {
+	obj, ok, err := cachedGet[v1.Pod](ctx, c.cache, c.informer(), v1.Resource("pods"), c.namespace, false, name)
+	if !ok {
+		return c.PodInterface.Get(ctx, name, opts)
+	}
+	return obj, err
+}
+
+type cachedEvents struct {
+	corev1client.EventInterface
+	cache     *informerCache
+	namespace string
+}
+
+func (c cachedEvents) List(ctx context.Context, opts metav1.ListOptions) (*v1.EventList, error) {
+	items, ok, err := cachedList[v1.Event](ctx, c.cache, c.cache.factory.Core().V1().Events().Informer(),
+		c.namespace, false, opts, EventFields)
+	if !ok {
+		return c.EventInterface.List(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v1.EventList{Items: items}, nil
+}
+
+type cachedServices struct {
+	corev1client.ServiceInterface
+	cache     *informerCache
+	namespace string
+}
+
+func (c cachedServices) informer() cache.SharedIndexInformer {
+	return c.cache.factory.Core().V1().Services().Informer()
+}
+
+func (c cachedServices) List(ctx context.Context, opts metav1.ListOptions) (*v1.ServiceList, error) {
+	items, ok, err := cachedList[v1.Service](ctx, c.cache, c.informer(), c.namespace, false, opts, nil)
+	if !ok {
+		return c.ServiceInterface.List(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ServiceList{Items: items}, nil
+}
+
+func (c cachedServices) Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.Service, error) {
+	obj, ok, err := cachedGet[v1.Service](ctx, c.cache, c.informer(), v1.Resource("services"), c.namespace, false, name)
+	if !ok {
+		return c.ServiceInterface.Get(ctx, name, opts)
+	}
+	return obj, err
+}
+
+type cachedEndpoints struct {
+	corev1client.EndpointsInterface
+	cache     *informerCache
+	namespace string
+}
+
+func (c cachedEndpoints) List(ctx context.Context, opts metav1.ListOptions) (*v1.EndpointsList, error) {
+	items, ok, err := cachedList[v1.Endpoints](ctx, c.cache, c.cache.factory.Core().V1().Endpoints().Informer(),
+		c.namespace, false, opts, nil)
+	if !ok {
+		return c.EndpointsInterface.List(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v1.EndpointsList{Items: items}, nil
+}
+
+type cachedPersistentVolumeClaims struct {
+	corev1client.PersistentVolumeClaimInterface
+	cache     *informerCache
+	namespace string
+}
+
+func (c cachedPersistentVolumeClaims) List(ctx context.Context, opts metav1.ListOptions) (*v1.PersistentVolumeClaimList, error) {
+	items, ok, err := cachedList[v1.PersistentVolumeClaim](ctx, c.cache,
+		c.cache.factory.Core().V1().PersistentVolumeClaims().Informer(), c.namespace, false, opts, nil)
+	if !ok {
+		return c.PersistentVolumeClaimInterface.List(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v1.PersistentVolumeClaimList{Items: items}, nil
+}
+
+type cachedReplicationControllers struct {
+	corev1client.ReplicationControllerInterface
+	cache     *informerCache
+	namespace string
+}
+
+func (c cachedReplicationControllers) Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.ReplicationController, error) {
+	obj, ok, err := cachedGet[v1.ReplicationController](ctx, c.cache,
+		c.cache.factory.Core().V1().ReplicationControllers().Informer(), v1.Resource("replicationcontrollers"),
+		c.namespace, false, name)
+	if !ok {
+		return c.ReplicationControllerInterface.Get(ctx, name, opts)
+	}
+	return obj, err
+}
+
+type cachedNodes struct {
+	corev1client.NodeInterface
+	cache *informerCache
+}
+
+func (c cachedNodes) List(ctx context.Context, opts metav1.ListOptions) (*v1.NodeList, error) {
+	items, ok, err := cachedList[v1.Node](ctx, c.cache, c.cache.factory.Core().V1().Nodes().Informer(),
+		"", true, opts, nil)
+	if !ok {
+		return c.NodeInterface.List(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v1.NodeList{Items: items}, nil
+}
+
+// apps/v1
+
+type cachedAppsV1 struct {
+	appsv1client.AppsV1Interface
+	cache *informerCache
+}
+
+func (c cachedAppsV1) Deployments(namespace string) appsv1client.DeploymentInterface {
+	return cachedDeployments{c.AppsV1Interface.Deployments(namespace), c.cache, namespace}
+}
+
+func (c cachedAppsV1) ReplicaSets(namespace string) appsv1client.ReplicaSetInterface {
+	return cachedReplicaSets{c.AppsV1Interface.ReplicaSets(namespace), c.cache, namespace}
+}
+
+func (c cachedAppsV1) StatefulSets(namespace string) appsv1client.StatefulSetInterface {
+	return cachedStatefulSets{c.AppsV1Interface.StatefulSets(namespace), c.cache, namespace}
+}
+
+func (c cachedAppsV1) DaemonSets(namespace string) appsv1client.DaemonSetInterface {
+	return cachedDaemonSets{c.AppsV1Interface.DaemonSets(namespace), c.cache, namespace}
+}
+
+func (c cachedAppsV1) ControllerRevisions(namespace string) appsv1client.ControllerRevisionInterface {
+	return cachedControllerRevisions{c.AppsV1Interface.ControllerRevisions(namespace), c.cache, namespace}
+}
+
+type cachedDeployments struct {
+	appsv1client.DeploymentInterface
+	cache     *informerCache
+	namespace string
+}
+
+func (c cachedDeployments) informer() cache.SharedIndexInformer {
+	return c.cache.factory.Apps().V1().Deployments().Informer()
+}
+
+func (c cachedDeployments) List(ctx context.Context, opts metav1.ListOptions) (*appsv1.DeploymentList, error) {
+	items, ok, err := cachedList[appsv1.Deployment](ctx, c.cache, c.informer(), c.namespace, false, opts, nil)
+	if !ok {
+		return c.DeploymentInterface.List(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &appsv1.DeploymentList{Items: items}, nil
+}
+
+func (c cachedDeployments) Get(ctx context.Context, name string, opts metav1.GetOptions) (*appsv1.Deployment, error) {
+	obj, ok, err := cachedGet[appsv1.Deployment](ctx, c.cache, c.informer(), appsv1.Resource("deployments"),
+		c.namespace, false, name)
+	if !ok {
+		return c.DeploymentInterface.Get(ctx, name, opts)
+	}
+	return obj, err
+}
+
+type cachedReplicaSets struct {
+	appsv1client.ReplicaSetInterface
+	cache     *informerCache
+	namespace string
+}
+
+func (c cachedReplicaSets) informer() cache.SharedIndexInformer {
+	return c.cache.factory.Apps().V1().ReplicaSets().Informer()
+}
+
+func (c cachedReplicaSets) List(ctx context.Context, opts metav1.ListOptions) (*appsv1.ReplicaSetList, error) {
+	items, ok, err := cachedList[appsv1.ReplicaSet](ctx, c.cache, c.informer(), c.namespace, false, opts, nil)
+	if !ok {
+		return c.ReplicaSetInterface.List(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &appsv1.ReplicaSetList{Items: items}, nil
+}
+
+func (c cachedReplicaSets) Get(ctx context.Context, name string, opts metav1.GetOptions) (*appsv1.ReplicaSet, error) {
+	obj, ok, err := cachedGet[appsv1.ReplicaSet](ctx, c.cache, c.informer(), appsv1.Resource("replicasets"),
+		c.namespace, false, name)
+	if !ok {
+		return c.ReplicaSetInterface.Get(ctx, name, opts)
+	}
+	return obj, err
+}
+
+type cachedStatefulSets struct {
+	appsv1client.StatefulSetInterface
+	cache     *informerCache
+	namespace string
+}
+
+func (c cachedStatefulSets) informer() cache.SharedIndexInformer {
+	return c.cache.factory.Apps().V1().StatefulSets().Informer()
+}
+
+func (c cachedStatefulSets) List(ctx context.Context, opts metav1.ListOptions) (*appsv1.StatefulSetList, error) {
+	items, ok, err := cachedList[appsv1.StatefulSet](ctx, c.cache, c.informer(), c.namespace, false, opts, nil)
+	if !ok {
+		return c.StatefulSetInterface.List(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &appsv1.StatefulSetList{Items: items}, nil
+}
+
+func (c cachedStatefulSets) Get(ctx context.Context, name string, opts metav1.GetOptions) (*appsv1.StatefulSet, error) {
+	obj, ok, err := cachedGet[appsv1.StatefulSet](ctx, c.cache, c.informer(), appsv1.Resource("statefulsets"),
+		c.namespace, false, name)
+	if !ok {
+		return c.StatefulSetInterface.Get(ctx, name, opts)
+	}
+	return obj, err
+}
+
+type cachedDaemonSets struct {
+	appsv1client.DaemonSetInterface
+	cache     *informerCache
+	namespace string
+}
+
+func (c cachedDaemonSets) informer() cache.SharedIndexInformer {
+	return c.cache.factory.Apps().V1().DaemonSets().Informer()
+}
+
+func (c cachedDaemonSets) List(ctx context.Context, opts metav1.ListOptions) (*appsv1.DaemonSetList, error) {
+	items, ok, err := cachedList[appsv1.DaemonSet](ctx, c.cache, c.informer(), c.namespace, false, opts, nil)
+	if !ok {
+		return c.DaemonSetInterface.List(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &appsv1.DaemonSetList{Items: items}, nil
+}
+
+func (c cachedDaemonSets) Get(ctx context.Context, name string, opts metav1.GetOptions) (*appsv1.DaemonSet, error) {
+	obj, ok, err := cachedGet[appsv1.DaemonSet](ctx, c.cache, c.informer(), appsv1.Resource("daemonsets"),
+		c.namespace, false, name)
+	if !ok {
+		return c.DaemonSetInterface.Get(ctx, name, opts)
+	}
+	return obj, err
+}
+
+type cachedControllerRevisions struct {
+	appsv1client.ControllerRevisionInterface
+	cache     *informerCache
+	namespace string
+}
+
+func (c cachedControllerRevisions) Get(ctx context.Context, name string, opts metav1.GetOptions) (*appsv1.ControllerRevision, error) {
+	obj, ok, err := cachedGet[appsv1.ControllerRevision](ctx, c.cache,
+		c.cache.factory.Apps().V1().ControllerRevisions().Informer(), appsv1.Resource("controllerrevisions"),
+		c.namespace, false, name)
+	if !ok {
+		return c.ControllerRevisionInterface.Get(ctx, name, opts)
+	}
+	return obj, err
+}
+
+// batch/v1
+
+type cachedBatchV1 struct {
+	batchv1client.BatchV1Interface
+	cache *informerCache
+}
+
+func (c cachedBatchV1) Jobs(namespace string) batchv1client.JobInterface {
+	return cachedJobs{c.BatchV1Interface.Jobs(namespace), c.cache, namespace}
+}
+
+func (c cachedBatchV1) CronJobs(namespace string) batchv1client.CronJobInterface {
+	return cachedCronJobs{c.BatchV1Interface.CronJobs(namespace), c.cache, namespace}
+}
+
+type cachedJobs struct {
+	batchv1client.JobInterface
+	cache     *informerCache
+	namespace string
+}
+
+func (c cachedJobs) List(ctx context.Context, opts metav1.ListOptions) (*batchv1.JobList, error) {
+	items, ok, err := cachedList[batchv1.Job](ctx, c.cache, c.cache.factory.Batch().V1().Jobs().Informer(),
+		c.namespace, false, opts, nil)
+	if !ok {
+		return c.JobInterface.List(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &batchv1.JobList{Items: items}, nil
+}
+
+type cachedCronJobs struct {
+	batchv1client.CronJobInterface
+	cache     *informerCache
+	namespace string
+}
+
+func (c cachedCronJobs) List(ctx context.Context, opts metav1.ListOptions) (*batchv1.CronJobList, error) {
+	items, ok, err := cachedList[batchv1.CronJob](ctx, c.cache, c.cache.factory.Batch().V1().CronJobs().Informer(),
+		c.namespace, false, opts, nil)
+	if !ok {
+		return c.CronJobInterface.List(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &batchv1.CronJobList{Items: items}, nil
+}
+
+// networking/v1
+
+type cachedNetworkingV1 struct {
+	networkingv1client.NetworkingV1Interface
+	cache *informerCache
+}
+
+func (c cachedNetworkingV1) Ingresses(namespace string) networkingv1client.IngressInterface {
+	return cachedIngresses{c.NetworkingV1Interface.Ingresses(namespace), c.cache, namespace}
+}
+
+func (c cachedNetworkingV1) IngressClasses() networkingv1client.IngressClassInterface {
+	return cachedIngressClasses{c.NetworkingV1Interface.IngressClasses(), c.cache}
+}
+
+type cachedIngresses struct {
+	networkingv1client.IngressInterface
+	cache     *informerCache
+	namespace string
+}
+
+func (c cachedIngresses) informer() cache.SharedIndexInformer {
+	return c.cache.factory.Networking().V1().Ingresses().Informer()
+}
+
+func (c cachedIngresses) List(ctx context.Context, opts metav1.ListOptions) (*networkingv1.IngressList, error) {
+	items, ok, err := cachedList[networkingv1.Ingress](ctx, c.cache, c.informer(), c.namespace, false, opts, nil)
+	if !ok {
+		return c.IngressInterface.List(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &networkingv1.IngressList{Items: items}, nil
+}
+
+func (c cachedIngresses) Get(ctx context.Context, name string, opts metav1.GetOptions) (*networkingv1.Ingress, error) {
+	obj, ok, err := cachedGet[networkingv1.Ingress](ctx, c.cache, c.informer(), networkingv1.Resource("ingresses"),
+		c.namespace, false, name)
+	if !ok {
+		return c.IngressInterface.Get(ctx, name, opts)
+	}
+	return obj, err
+}
+
+type cachedIngressClasses struct {
+	networkingv1client.IngressClassInterface
+	cache *informerCache
+}
+
+func (c cachedIngressClasses) Get(ctx context.Context, name string, opts metav1.GetOptions) (*networkingv1.IngressClass, error) {
+	obj, ok, err := cachedGet[networkingv1.IngressClass](ctx, c.cache,
+		c.cache.factory.Networking().V1().IngressClasses().Informer(), networkingv1.Resource("ingressclasses"),
+		"", true, name)
+	if !ok {
+		return c.IngressClassInterface.Get(ctx, name, opts)
+	}
+	return obj, err
+}
+
+// storage/v1
+
+type cachedStorageV1 struct {
+	storagev1client.StorageV1Interface
+	cache *informerCache
+}
+
+func (c cachedStorageV1) StorageClasses() storagev1client.StorageClassInterface {
+	return cachedStorageClasses{c.StorageV1Interface.StorageClasses(), c.cache}
+}
+
+type cachedStorageClasses struct {
+	storagev1client.StorageClassInterface
+	cache *informerCache
+}
+
+func (c cachedStorageClasses) Get(ctx context.Context, name string, opts metav1.GetOptions) (*storagev1.StorageClass, error) {
+	obj, ok, err := cachedGet[storagev1.StorageClass](ctx, c.cache,
+		c.cache.factory.Storage().V1().StorageClasses().Informer(), storagev1.Resource("storageclasses"),
+		"", true, name)
+	if !ok {
+		return c.StorageClassInterface.Get(ctx, name, opts)
+	}
+	return obj, err
+}
+
+// autoscaling/v1
+
+type cachedAutoscalingV1 struct {
+	autoscalingv1client.AutoscalingV1Interface
+	cache *informerCache
+}
+
+func (c cachedAutoscalingV1) HorizontalPodAutoscalers(namespace string) autoscalingv1client.HorizontalPodAutoscalerInterface {
+	return cachedHorizontalPodAutoscalers{c.AutoscalingV1Interface.HorizontalPodAutoscalers(namespace), c.cache, namespace}
+}
+
+type cachedHorizontalPodAutoscalers struct {
+	autoscalingv1client.HorizontalPodAutoscalerInterface
+	cache     *informerCache
+	namespace string
+}
+
+func (c cachedHorizontalPodAutoscalers) List(ctx context.Context, opts metav1.ListOptions) (*autoscalingv1.HorizontalPodAutoscalerList, error) {
+	items, ok, err := cachedList[autoscalingv1.HorizontalPodAutoscaler](ctx, c.cache,
+		c.cache.factory.Autoscaling().V1().HorizontalPodAutoscalers().Informer(), c.namespace, false, opts, nil)
+	if !ok {
+		return c.HorizontalPodAutoscalerInterface.List(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &autoscalingv1.HorizontalPodAutoscalerList{Items: items}, nil
+}
+
+// policy/v1
+
+type cachedPolicyV1 struct {
+	policyv1client.PolicyV1Interface
+	cache *informerCache
+}
+
+func (c cachedPolicyV1) PodDisruptionBudgets(namespace string) policyv1client.PodDisruptionBudgetInterface {
+	return cachedPodDisruptionBudgets{c.PolicyV1Interface.PodDisruptionBudgets(namespace), c.cache, namespace}
+}
+
+type cachedPodDisruptionBudgets struct {
+	policyv1client.PodDisruptionBudgetInterface
+	cache     *informerCache
+	namespace string
+}
+
+func (c cachedPodDisruptionBudgets) List(ctx context.Context, opts metav1.ListOptions) (*policyv1.PodDisruptionBudgetList, error) {
+	items, ok, err := cachedList[policyv1.PodDisruptionBudget](ctx, c.cache,
+		c.cache.factory.Policy().V1().PodDisruptionBudgets().Informer(), c.namespace, false, opts, nil)
+	if !ok {
+		return c.PodDisruptionBudgetInterface.List(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &policyv1.PodDisruptionBudgetList{Items: items}, nil
+}
@@ -0,0 +1,78 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCachedClient(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}},
+			Spec:       v1.PodSpec{NodeName: "node-1"},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", Labels: map[string]string{"app": "db"}},
+			Spec:       v1.PodSpec{NodeName: "node-2"},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "kube-system"},
+		},
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0, informers.WithNamespace("default"))
+	client := NewCachedClient(&Client{Client: clientset}, factory, "default", ctx.Done())
+
+	podLists := func() int {
+		count := 0
+		for _, action := range clientset.Actions() {
+			if action.GetVerb() == "list" && action.GetResource().Resource == "pods" {
+				count++
+			}
+		}
+		return count
+	}
+
+	for i := 0; i < 3; i++ {
+		pods, err := client.GetClient().CoreV1().Pods("default").List(ctx, metav1.ListOptions{
+			LabelSelector: "app=web",
+			FieldSelector: "spec.nodeName=node-1",
+		})
+		assert.Equal(t, err, nil)
+		assert.Equal(t, len(pods.Items), 1)
+		assert.Equal(t, pods.Items[0].Name, "web")
+	}
+	// only the informer listed the pods
+	assert.Equal(t, podLists(), 1)
+
+	pod, err := client.GetClient().CoreV1().Pods("default").Get(ctx, "db", metav1.GetOptions{})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, pod.Spec.NodeName, "node-2")
+	_, err = client.GetClient().CoreV1().Pods("default").Get(ctx, "missing", metav1.GetOptions{})
+	assert.Equal(t, apierrors.IsNotFound(err), true)
+
+	// the cached objects are copies
+	pod.Labels["app"] = "changed"
+	pod, err = client.GetClient().CoreV1().Pods("default").Get(ctx, "db", metav1.GetOptions{})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, pod.Labels["app"], "db")
+
+	// namespaces outside of the informers and unknown fields go to the API
+	pods, err := client.GetClient().CoreV1().Pods("kube-system").List(ctx, metav1.ListOptions{})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(pods.Items), 1)
+	assert.Equal(t, podLists(), 2)
+	_, err = client.GetClient().CoreV1().Pods("default").List(ctx, metav1.ListOptions{
+		FieldSelector: "spec.serviceAccountName=default",
+	})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, podLists(), 3)
+}
@@ -0,0 +1,31 @@
+package kubernetes
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// PodFields returns the fields of a pod that can be selected on
+func PodFields(pod *v1.Pod) fields.Set {
+	return fields.Set{
+		"metadata.name":      pod.Name,
+		"metadata.namespace": pod.Namespace,
+		"spec.nodeName":      pod.Spec.NodeName,
+		"status.phase":       string(pod.Status.Phase),
+	}
+}
+
+// EventFields returns the fields of an event that can be selected on
+func EventFields(event *v1.Event) fields.Set {
+	return fields.Set{
+		"metadata.name":             event.Name,
+		"metadata.namespace":        event.Namespace,
+		"involvedObject.kind":       event.InvolvedObject.Kind,
+		"involvedObject.name":       event.InvolvedObject.Name,
+		"involvedObject.namespace":  event.InvolvedObject.Namespace,
+		"involvedObject.uid":        string(event.InvolvedObject.UID),
+		"involvedObject.apiVersion": event.InvolvedObject.APIVersion,
+		"reason":                    event.Reason,
+		"type":                      event.Type,
+	}
+}
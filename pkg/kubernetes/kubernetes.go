@@ -1,6 +1,8 @@
 package kubernetes
 
 import (
+	"sort"
+
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 )
@@ -34,3 +36,19 @@ func NewClient(kubecontext string, kubeconfig string) (*Client, error) {
 		Client: clientSet,
 	}, nil
 }
+
+// ListContexts returns the names of the contexts of a kubeconfig, sorted
+func ListContexts(kubeconfig string) ([]string, error) {
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig},
+		&clientcmd.ConfigOverrides{}).RawConfig()
+	if err != nil {
+		return nil, err
+	}
+	contexts := make([]string, 0, len(config.Contexts))
+	for name := range config.Contexts {
+		contexts = append(contexts, name)
+	}
+	sort.Strings(contexts)
+	return contexts, nil
+}
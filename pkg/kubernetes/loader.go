@@ -0,0 +1,29 @@
+package kubernetes
+
+import (
+	"sync"
+)
+
+// Loader creates the client on first use, so that commands which do not
+// talk to the cluster work without a kubeconfig
+type Loader struct {
+	Context    string
+	Kubeconfig string
+
+	once   sync.Once
+	client *Client
+	err    error
+}
+
+// DefaultLoader is the loader configured by the --kubecontext and
+// --kubeconfig flags
+var DefaultLoader = &Loader{}
+
+// Client returns the client of the loader context, creating it on the first
+// call. Later calls return the same client or error.
+func (l *Loader) Client() (*Client, error) {
+	l.once.Do(func() {
+		l.client, l.err = NewClient(l.Context, l.Kubeconfig)
+	})
+	return l.client, l.err
+}
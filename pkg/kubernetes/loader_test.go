@@ -0,0 +1,63 @@
+package kubernetes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    token: test
+`
+
+func TestLoader(t *testing.T) {
+
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	err := os.WriteFile(kubeconfig, []byte(testKubeconfig), 0600)
+	assert.Equal(t, err, nil)
+
+	loader := &Loader{Kubeconfig: kubeconfig}
+	client, err := loader.Client()
+	assert.Equal(t, err, nil)
+	again, _ := loader.Client()
+	assert.Equal(t, client == again, true)
+
+	// the error of a missing kubeconfig is only returned when the client is
+	// needed
+	loader = &Loader{Kubeconfig: filepath.Join(t.TempDir(), "missing")}
+	_, err = loader.Client()
+	assert.Equal(t, err != nil, true)
+}
+
+func TestListContexts(t *testing.T) {
+
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	err := os.WriteFile(kubeconfig, []byte(strings.Replace(testKubeconfig, "contexts:\n", `contexts:
+- name: production
+  context:
+    cluster: test
+    user: test
+`, 1)), 0600)
+	assert.Equal(t, err, nil)
+
+	contexts, err := ListContexts(kubeconfig)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, contexts, []string{"production", "test"})
+}
@@ -0,0 +1,210 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/ai"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/analyzer"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/viper"
+)
+
+const (
+	// shutdownTimeout is how long in-flight requests are given to complete
+	// when the server stops
+	shutdownTimeout = 30 * time.Second
+	// maxRequestSize caps the size of request bodies
+	maxRequestSize = 1 << 20
+)
+
+// Server exposes the analysis as a REST API, and its metrics in the
+// Prometheus format on /metrics. Config holds the settings
+// shared by all requests, such as the cache and prompt templates, and
+// AIClient may be nil, in which case explanations cannot be requested.
+// Only the requests analyzing the whole cluster with the active filters
+// update the findings metric, the others are scoped to part of it.
+type Server struct {
+	Address  string
+	Config   analyzer.AnalysisConfiguration
+	Client   *kubernetes.Client
+	AIClient ai.IAI
+}
+
+// AnalyzeRequest is the body of POST /v1/analyze
+type AnalyzeRequest struct {
+	Namespace   string   `json:"namespace"`
+	Filters     []string `json:"filters"`
+	Explain     bool     `json:"explain"`
+	Language    string   `json:"language"`
+	MinSeverity string   `json:"minSeverity"`
+	// Selector is a label selector, objects of ExcludeNamespaces are not
+	// analyzed
+	Selector          string   `json:"selector"`
+	ExcludeNamespaces []string `json:"excludeNamespaces"`
+}
+
+// clusterWide reports whether the request analyzes the whole cluster with
+// the active filters, and reports the failures of all severities
+func (r AnalyzeRequest) clusterWide() bool {
+	return r.Namespace == "" && len(r.Filters) == 0 && r.Selector == "" &&
+		len(r.ExcludeNamespaces) == 0 && r.MinSeverity == analyzer.SeverityInfo.String()
+}
+
+// FiltersResponse is the body returned by GET /v1/filters
+type FiltersResponse struct {
+	Core       []string `json:"core"`
+	Additional []string `json:"additional"`
+	Active     []string `json:"active"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/analyze", s.analyze)
+	mux.HandleFunc("/v1/filters", s.filters)
+	mux.HandleFunc("/healthz", s.healthz)
+	mux.Handle("/metrics", promhttp.Handler())
+	return mux
+}
+
+// Serve listens on the server address until ctx is done, then waits for
+// in-flight requests to complete before returning
+func (s *Server) Serve(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:              s.Address,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) analyze(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+
+	request := AnalyzeRequest{
+		Language:    "english",
+		MinSeverity: analyzer.SeverityInfo.String(),
+	}
+	// an empty body analyzes all namespaces with the default settings
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&request)
+	if err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	severity, err := analyzer.ParseSeverity(request.MinSeverity)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if request.Explain && s.AIClient == nil {
+		writeError(w, http.StatusBadRequest, errors.New("no AI backend configured, please run k8sgpt auth"))
+		return
+	}
+
+	config := s.Config
+	config.Namespace = request.Namespace
+	config.Explain = request.Explain
+	config.Language = request.Language
+	config.Selector = request.Selector
+	config.ExcludeNamespaces = request.ExcludeNamespaces
+	if err := config.ValidateSelector(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// the request context cancels the analysis when the client goes away
+	ctx := r.Context()
+	var analysisResults []analyzer.Analysis
+	analysisErr := analyzer.RunAnalysis(ctx, request.Filters, &config, s.Client, s.AIClient, &analysisResults)
+	analysisResults = analyzer.FilterSeverity(analysisResults, severity)
+	ignoreRules, err := analyzer.LoadIgnoreRules()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	analysisResults, suppressed := analyzer.ApplyIgnoreRules(analysisResults, ignoreRules, time.Now())
+	// the findings of analyzers that failed would be missing from the metric
+	if request.clusterWide() && analysisErr == nil {
+		analyzer.RecordFindings(analysisResults)
+	}
+
+	if request.Explain {
+		err := analyzer.ExplainAnalysis(ctx, &config, s.AIClient, analysisResults, nil)
+		if ai.IsRateLimited(err) {
+			writeError(w, http.StatusTooManyRequests, err)
+			return
+		}
+		analysisErr = errors.Join(analysisErr, err)
+	}
+
+	output := analyzer.NewOutput(analysisResults, analysisErr)
+	output.Suppressed = suppressed
+	writeJSON(w, http.StatusOK, output)
+}
+
+func (s *Server) filters(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+
+	coreFilters, additionalFilters := analyzer.ListFilters()
+	activeFilters := viper.GetStringSlice("active_filters")
+	if len(activeFilters) == 0 {
+		activeFilters = coreFilters
+	}
+	sort.Strings(coreFilters)
+	sort.Strings(additionalFilters)
+	writeJSON(w, http.StatusOK, FiltersResponse{
+		Core:       coreFilters,
+		Additional: additionalFilters,
+		Active:     activeFilters,
+	})
+}
+
+func (s *Server) healthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,175 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/analyzer"
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/magiconair/properties/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestServer() *Server {
+	clientset := fake.NewSimpleClientset(
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{Name: "app", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ErrImagePull"}}},
+				},
+			},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "other"},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{Name: "app", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ErrImagePull"}}},
+				},
+			},
+		})
+	return &Server{
+		Client: &kubernetes.Client{Client: clientset},
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+
+	server := newTestServer()
+
+	tests := []struct {
+		name     string
+		method   string
+		body     string
+		status   int
+		problems int
+	}{
+		{
+			name:     "namespace",
+			method:   http.MethodPost,
+			body:     `{"namespace": "default", "filters": ["Pod"]}`,
+			status:   http.StatusOK,
+			problems: 1,
+		},
+		{
+			name:     "all namespaces",
+			method:   http.MethodPost,
+			body:     `{"filters": ["Pod"]}`,
+			status:   http.StatusOK,
+			problems: 2,
+		},
+		{
+			name:   "minimum severity",
+			method: http.MethodPost,
+			body:   `{"filters": ["Pod"], "minSeverity": "critical"}`,
+			status: http.StatusOK,
+			// image pull errors are critical
+			problems: 2,
+		},
+		{
+			name:   "explain without backend",
+			method: http.MethodPost,
+			body:   `{"explain": true}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid body",
+			method: http.MethodPost,
+			body:   `{"filters": "Pod"}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "wrong method",
+			method: http.MethodGet,
+			status: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(tt.method, "/v1/analyze", strings.NewReader(tt.body))
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, request)
+			assert.Equal(t, recorder.Code, tt.status)
+
+			if tt.status == http.StatusOK {
+				var output analyzer.Output
+				err := json.NewDecoder(recorder.Body).Decode(&output)
+				assert.Equal(t, err, nil)
+				assert.Equal(t, output.Problems, tt.problems)
+				assert.Equal(t, output.Status, analyzer.StatusProblemDetected)
+			}
+		})
+	}
+}
+
+func TestFiltersAndHealth(t *testing.T) {
+
+	server := newTestServer()
+
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/filters", nil))
+	assert.Equal(t, recorder.Code, http.StatusOK)
+	var filters FiltersResponse
+	err := json.NewDecoder(recorder.Body).Decode(&filters)
+	assert.Equal(t, err, nil)
+	core, additional := analyzer.ListFilters()
+	assert.Equal(t, len(filters.Core), len(core))
+	assert.Equal(t, len(filters.Additional), len(additional))
+
+	recorder = httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, recorder.Code, http.StatusOK)
+}
+
+func TestServeShutdown(t *testing.T) {
+
+	server := newTestServer()
+	server.Address = "127.0.0.1:0"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- server.Serve(ctx)
+	}()
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.Equal(t, err, nil)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+}
+
+func TestMetrics(t *testing.T) {
+
+	server := newTestServer()
+	analyzer.RecordFindings(nil)
+
+	metrics := func(body string) string {
+		request := httptest.NewRequest(http.MethodPost, "/v1/analyze", strings.NewReader(body))
+		server.Handler().ServeHTTP(httptest.NewRecorder(), request)
+
+		recorder := httptest.NewRecorder()
+		server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		assert.Equal(t, recorder.Code, http.StatusOK)
+		return recorder.Body.String()
+	}
+
+	// scoped requests do not replace the findings of the whole cluster
+	body := metrics(`{"namespace": "default", "filters": ["Pod"]}`)
+	assert.Equal(t, strings.Contains(body, `k8sgpt_findings{`), false)
+	assert.Equal(t, strings.Contains(body, `k8sgpt_analyzer_duration_seconds_count{analyzer="Pod"}`), true)
+
+	body = metrics("")
+	assert.Equal(t, strings.Contains(body,
+		`k8sgpt_findings{kind="Pod",namespace="other",reason="ErrImagePull",severity="critical"} 1`), true)
+}
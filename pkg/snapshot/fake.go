@@ -0,0 +1,62 @@
+package snapshot
+
+import (
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// fieldSets returns the fields that can be selected on, for the resources
+// listed with a field selector by the analyzers
+var fieldSets = map[string]struct {
+	kind   string
+	fields func(runtime.Object) fields.Set
+}{
+	"pods": {"Pod", func(obj runtime.Object) fields.Set {
+		return kubernetes.PodFields(obj.(*v1.Pod))
+	}},
+	"events": {"Event", func(obj runtime.Object) fields.Set {
+		return kubernetes.EventFields(obj.(*v1.Event))
+	}},
+}
+
+// newFakeClientset returns a fake clientset holding objects. Unlike the
+// default one it honours the field selectors used by the analyzers.
+func newFakeClientset(objects ...runtime.Object) *fake.Clientset {
+	clientset := fake.NewSimpleClientset(objects...)
+	clientset.PrependReactor("list", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		list, ok := action.(k8stesting.ListAction)
+		if !ok {
+			return false, nil, nil
+		}
+		selector := list.GetListRestrictions().Fields
+		fieldSet, ok := fieldSets[action.GetResource().Resource]
+		if !ok || selector == nil || selector.Empty() {
+			return false, nil, nil
+		}
+
+		gvr := action.GetResource()
+		gvk := schema.GroupVersionKind{Group: gvr.Group, Version: gvr.Version, Kind: fieldSet.kind}
+		obj, err := clientset.Tracker().List(gvr, gvk, action.GetNamespace())
+		if err != nil {
+			return true, nil, err
+		}
+		items, err := meta.ExtractList(obj)
+		if err != nil {
+			return true, nil, err
+		}
+		var selected []runtime.Object
+		for _, item := range items {
+			if selector.Matches(fieldSet.fields(item)) {
+				selected = append(selected, item)
+			}
+		}
+		return true, obj, meta.SetList(obj, selected)
+	})
+	return clientset
+}
@@ -0,0 +1,253 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+// metadataFile describes the snapshot, the other files each hold the list
+// of one resource
+const metadataFile = "snapshot.json"
+
+// Metadata records where and when a snapshot was taken, and the resources
+// that could not be saved
+type Metadata struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Namespace string    `json:"namespace,omitempty"`
+	Skipped   []string  `json:"skipped,omitempty"`
+}
+
+// optionalResources are skipped when listing them is forbidden, listing
+// secrets in all namespaces needs permissions few users have
+var optionalResources = map[string]bool{
+	"secrets": true,
+}
+
+// resource is a kind read by the analyzers. list returns its objects, in
+// namespace for namespaced kinds, and newList an empty list to decode them.
+type resource struct {
+	name    string
+	list    func(ctx context.Context, client k8s.Interface, namespace string) (runtime.Object, error)
+	newList func() runtime.Object
+}
+
+var resources = []resource{
+	{"pods", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &v1.PodList{} }},
+	{"events", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.CoreV1().Events(ns).List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &v1.EventList{} }},
+	{"services", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.CoreV1().Services(ns).List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &v1.ServiceList{} }},
+	{"endpoints", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.CoreV1().Endpoints(ns).List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &v1.EndpointsList{} }},
+	{"persistentvolumeclaims", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.CoreV1().PersistentVolumeClaims(ns).List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &v1.PersistentVolumeClaimList{} }},
+	{"replicationcontrollers", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.CoreV1().ReplicationControllers(ns).List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &v1.ReplicationControllerList{} }},
+	{"secrets", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.CoreV1().Secrets(ns).List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &v1.SecretList{} }},
+	{"nodes", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &v1.NodeList{} }},
+	{"deployments", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.AppsV1().Deployments(ns).List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &appsv1.DeploymentList{} }},
+	{"replicasets", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.AppsV1().ReplicaSets(ns).List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &appsv1.ReplicaSetList{} }},
+	{"controllerrevisions", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.AppsV1().ControllerRevisions(ns).List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &appsv1.ControllerRevisionList{} }},
+	{"statefulsets", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.AppsV1().StatefulSets(ns).List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &appsv1.StatefulSetList{} }},
+	{"daemonsets", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.AppsV1().DaemonSets(ns).List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &appsv1.DaemonSetList{} }},
+	{"jobs", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.BatchV1().Jobs(ns).List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &batchv1.JobList{} }},
+	{"cronjobs", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.BatchV1().CronJobs(ns).List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &batchv1.CronJobList{} }},
+	{"ingresses", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.NetworkingV1().Ingresses(ns).List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &networkingv1.IngressList{} }},
+	{"ingressclasses", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.NetworkingV1().IngressClasses().List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &networkingv1.IngressClassList{} }},
+	{"storageclasses", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &storagev1.StorageClassList{} }},
+	{"horizontalpodautoscalers", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.AutoscalingV1().HorizontalPodAutoscalers(ns).List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &autoscalingv1.HorizontalPodAutoscalerList{} }},
+	{"poddisruptionbudgets", func(ctx context.Context, c k8s.Interface, ns string) (runtime.Object, error) {
+		return c.PolicyV1().PodDisruptionBudgets(ns).List(ctx, metav1.ListOptions{})
+	}, func() runtime.Object { return &policyv1.PodDisruptionBudgetList{} }},
+}
+
+// Create writes a gzipped tarball of the resources read by the analyzers,
+// in namespace or in all namespaces when empty, with one JSON list per
+// resource, and returns its metadata. The data and annotations of secrets
+// are not included.
+func Create(ctx context.Context, client *kubernetes.Client, namespace string, w io.Writer) (Metadata, error) {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+	metadata := Metadata{CreatedAt: now, Namespace: namespace}
+
+	for _, r := range resources {
+		list, err := r.list(ctx, client.GetClient(), namespace)
+		if apierrors.IsForbidden(err) && optionalResources[r.name] {
+			metadata.Skipped = append(metadata.Skipped, r.name)
+			continue
+		}
+		if err != nil {
+			return metadata, fmt.Errorf("error listing %s: %w", r.name, err)
+		}
+		if err := strip(list); err != nil {
+			return metadata, err
+		}
+		if err := writeJSON(tw, r.name+".json", now, list); err != nil {
+			return metadata, err
+		}
+	}
+	if err := writeJSON(tw, metadataFile, now, metadata); err != nil {
+		return metadata, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return metadata, err
+	}
+	return metadata, gz.Close()
+}
+
+// strip removes the managed fields of the objects of list, and the data and
+// annotations of secrets, the analyzers only need to know that secrets
+// exist. The last applied configuration annotation of kubectl apply holds
+// the whole secret.
+func strip(list runtime.Object) error {
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if secret, ok := item.(*v1.Secret); ok {
+			secret.Data = nil
+			secret.StringData = nil
+			secret.Annotations = nil
+		}
+		if accessor, err := meta.Accessor(item); err == nil {
+			accessor.SetManagedFields(nil)
+		}
+	}
+	return meta.SetList(list, items)
+}
+
+func writeJSON(tw *tar.Writer, name string, modTime time.Time, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}); err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
+
+// Load reads a snapshot written by Create into a fake clientset, so that
+// the analyzers can run against it without access to the cluster, and
+// returns its metadata
+func Load(r io.Reader) (*kubernetes.Client, Metadata, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, Metadata{}, fmt.Errorf("error reading snapshot: %w", err)
+	}
+	defer gz.Close()
+
+	newLists := map[string]func() runtime.Object{}
+	for _, r := range resources {
+		newLists[r.name+".json"] = r.newList
+	}
+
+	var (
+		objects  []runtime.Object
+		metadata Metadata
+		found    bool
+	)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, Metadata{}, fmt.Errorf("error reading snapshot: %w", err)
+		}
+		name := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		if name == metadataFile {
+			if err := json.NewDecoder(tr).Decode(&metadata); err != nil {
+				return nil, Metadata{}, fmt.Errorf("error decoding %s: %w", name, err)
+			}
+			found = true
+			continue
+		}
+		// files of resources unknown to this version are skipped
+		newList, ok := newLists[name]
+		if !ok {
+			continue
+		}
+		list := newList()
+		if err := json.NewDecoder(tr).Decode(list); err != nil {
+			return nil, Metadata{}, fmt.Errorf("error decoding %s: %w", name, err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, Metadata{}, err
+		}
+		objects = append(objects, items...)
+	}
+	if !found {
+		return nil, Metadata{}, fmt.Errorf("not a k8sgpt snapshot, %s is missing", metadataFile)
+	}
+
+	return &kubernetes.Client{
+		Client: newFakeClientset(objects...),
+	}, metadata, nil
+}
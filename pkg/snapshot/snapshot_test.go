@@ -0,0 +1,126 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k8sgpt-ai/k8sgpt/pkg/kubernetes"
+	"github.com/magiconair/properties/assert"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCreateLoad(t *testing.T) {
+
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "default"},
+			Spec:       v1.PodSpec{NodeName: "node-1"},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "default"},
+			Spec:       v1.PodSpec{NodeName: "node-2"},
+		},
+		&v1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "a.1", Namespace: "default"},
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "a"},
+		},
+		&v1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "b.1", Namespace: "default"},
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "b"},
+		},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "default",
+				Annotations: map[string]string{
+					v1.LastAppliedConfigAnnotation: `{"data":{"tls.key":"cHJpdmF0ZQ=="}}`,
+				}},
+			Data: map[string][]byte{"tls.key": []byte("private")},
+		},
+		&v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		})
+
+	var buf bytes.Buffer
+	metadata, err := Create(ctx, &kubernetes.Client{Client: clientset}, "", &buf)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(metadata.Skipped), 0)
+
+	client, loadedMetadata, err := Load(&buf)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, loadedMetadata.CreatedAt.Equal(metadata.CreatedAt), true)
+	loaded := client.GetClient()
+
+	pods, err := loaded.CoreV1().Pods("default").List(ctx, metav1.ListOptions{})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(pods.Items), 2)
+
+	nodes, err := loaded.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(nodes.Items), 1)
+
+	// the data of secrets is not part of the snapshot, nor the annotation
+	// of kubectl apply holding it
+	secret, err := loaded.CoreV1().Secrets("default").Get(ctx, "tls", metav1.GetOptions{})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(secret.Data), 0)
+	_, ok := secret.Annotations[v1.LastAppliedConfigAnnotation]
+	assert.Equal(t, ok, false)
+
+	// field selectors used by the analyzers are honoured
+	events, err := loaded.CoreV1().Events("default").List(ctx, metav1.ListOptions{
+		FieldSelector: "involvedObject.name=b",
+	})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(events.Items), 1)
+	assert.Equal(t, events.Items[0].Name, "b.1")
+
+	pods, err = loaded.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: "spec.nodeName=node-1",
+	})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(pods.Items), 1)
+	assert.Equal(t, pods.Items[0].Name, "a")
+}
+
+func TestCreateSecretsForbidden(t *testing.T) {
+
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "default"},
+	})
+	clientset.PrependReactor("list", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(v1.Resource("secrets"), "", errors.New("no permission"))
+	})
+
+	// the snapshot is saved without the secrets
+	var buf bytes.Buffer
+	metadata, err := Create(ctx, &kubernetes.Client{Client: clientset}, "", &buf)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, metadata.Skipped, []string{"secrets"})
+
+	client, _, err := Load(&buf)
+	assert.Equal(t, err, nil)
+	pods, err := client.GetClient().CoreV1().Pods("default").List(ctx, metav1.ListOptions{})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(pods.Items), 1)
+
+	// other resources are required
+	clientset.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(v1.Resource("pods"), "", errors.New("no permission"))
+	})
+	_, err = Create(ctx, &kubernetes.Client{Client: clientset}, "", &bytes.Buffer{})
+	assert.Equal(t, err != nil, true)
+}
+
+func TestLoadInvalid(t *testing.T) {
+
+	_, _, err := Load(bytes.NewReader([]byte("not a tarball")))
+	assert.Equal(t, err != nil, true)
+}